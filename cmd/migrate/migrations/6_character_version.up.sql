@@ -0,0 +1 @@
+ALTER TABLE characters ADD COLUMN version BIGINT NOT NULL DEFAULT 0;
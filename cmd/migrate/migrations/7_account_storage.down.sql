@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_account_storage_locked_by;
+
+DROP TABLE IF EXISTS account_storage;
@@ -0,0 +1,14 @@
+CREATE TABLE account_storage (
+    account_id INTEGER PRIMARY KEY REFERENCES accounts(id) ON DELETE CASCADE,
+    items JSONB NOT NULL DEFAULT '[]',
+    woonz BIGINT NOT NULL DEFAULT 0,
+    version BIGINT NOT NULL DEFAULT 0,
+
+    locked_by INTEGER REFERENCES characters(id) ON DELETE SET NULL,
+    locked_at TIMESTAMP WITH TIME ZONE,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_account_storage_locked_by ON account_storage(locked_by);
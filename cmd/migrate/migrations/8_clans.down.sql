@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_clan_members_clan_id;
+DROP TABLE IF EXISTS clan_members;
+DROP TABLE IF EXISTS clans;
@@ -0,0 +1,20 @@
+CREATE TABLE clans (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(21) UNIQUE NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT valid_clan_name CHECK (name ~ '^[a-zA-Z0-9_-]{3,21}$')
+);
+
+CREATE TABLE clan_members (
+    character_id INTEGER PRIMARY KEY REFERENCES characters(id) ON DELETE CASCADE,
+    clan_id INTEGER NOT NULL REFERENCES clans(id) ON DELETE CASCADE,
+    rank SMALLINT NOT NULL DEFAULT 1,
+
+    joined_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_clan_members_clan_id ON clan_members(clan_id);
@@ -0,0 +1,10 @@
+CREATE TABLE clan_marks (
+    clan_id INTEGER PRIMARY KEY REFERENCES clans(id) ON DELETE CASCADE,
+    mark BYTEA NOT NULL,
+    version INTEGER NOT NULL DEFAULT 1,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT valid_mark_size CHECK (octet_length(mark) <= 1024)
+);
@@ -35,29 +35,32 @@ func main() {
 	)
 
 	players := zoneserver.NewPlayers()
+	gateSessions := zoneserver.NewGateSessions()
 
 	zoneManager := zoneserver.NewZoneManager(cfg, db, logger, cacheService.(*redis.Client), serialNumberGenerator, players)
-	go func(z *zoneserver.ZoneManager) {
-		err := z.Start()
-		if err != nil {
-			logger.Error("Failed to start zone manager", shared.Field{Key: "error", Value: err})
-			panic(err)
-		}
-	}(zoneManager)
-
 	mainServerClient := zoneserver.NewMainServerClient(
 		cfg.ServerId,
 		cfg.MainServerIpAddress+":"+cfg.MainServerPort,
 		logger,
 		players,
+		gateSessions,
 		zoneManager,
 		db,
 	)
+	zoneManager.SetMainServerClient(mainServerClient)
+	go func(z *zoneserver.ZoneManager) {
+		err := z.Start()
+		if err != nil {
+			logger.Error("Failed to start zone manager", shared.Field{Key: "error", Value: err})
+			panic(err)
+		}
+	}(zoneManager)
+
 	go func(c *zoneserver.MainServerClient) {
 		c.Start()
 	}(mainServerClient)
 
-	server := zoneserver.NewServer(cfg, db, logger, mainServerClient, players, zoneManager)
+	server := zoneserver.NewServer(cfg, db, logger, mainServerClient, players, gateSessions, zoneManager)
 	go func(s *zoneserver.Server) {
 		err := s.Start()
 		if err != nil {
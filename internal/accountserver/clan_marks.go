@@ -0,0 +1,154 @@
+package accountserver
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/constants"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages/protocol"
+)
+
+// Clan marks are uncompressed 24-bit bitmaps of a fixed size.
+const (
+	clanMarkWidth          = 16
+	clanMarkHeight         = 16
+	clanMarkBitCount       = 24
+	bitmapFileHeaderSize   = 14
+	bitmapInfoHeaderSize   = 40
+	bitmapCompressionNone  = 0
+	bitmapRowAlignment     = 4
+	clanMarkBytesPerPixel  = clanMarkBitCount / 8
+	clanMarkRowSize        = (clanMarkWidth*clanMarkBytesPerPixel + bitmapRowAlignment - 1) / bitmapRowAlignment * bitmapRowAlignment
+	clanMarkPixelArraySize = clanMarkRowSize * clanMarkHeight
+)
+
+var errInvalidClanMark = errors.New("clan mark is not a valid bitmap")
+
+// handleRegisterMark stores a new mark for the clan the uploading character
+// is master of.
+func (s *accountServerSession) handleRegisterMark(packet []byte) {
+	msg, err := messages.ReadMsgC2ZRegisterMark(packet)
+	if err != nil {
+		s.server.Logger.Error("Rejected clan mark upload",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "size", Value: len(packet)},
+		)
+		return
+	}
+
+	player, exists := s.server.players.Get(msg.PcId)
+	characterName := ""
+	if exists {
+		characterName = player.GetSelectedCharacterName()
+	}
+
+	if characterName == "" {
+		_ = s.Send(messages.NewMsgS2CRegisterMark(msg.PcId, 0, 0, constants.ClanMarkResultFailure).GetBytes())
+		return
+	}
+
+	clanId, err := s.server.dbService.GetMasterClanId(msg.PcId, characterName)
+	if err != nil || clanId == 0 {
+		_ = s.Send(messages.NewMsgS2CRegisterMark(msg.PcId, 0, 0, constants.ClanMarkResultFailure).GetBytes())
+		return
+	}
+
+	mark := msg.Mark[:msg.MarkSize]
+	if err := validateClanMark(mark); err != nil {
+		_ = s.Send(messages.NewMsgS2CRegisterMark(msg.PcId, clanId, 0, constants.ClanMarkResultInvalid).GetBytes())
+		return
+	}
+
+	version, err := s.server.dbService.SaveClanMark(clanId, mark)
+	if err != nil {
+		_ = s.Send(messages.NewMsgS2CRegisterMark(msg.PcId, clanId, 0, constants.ClanMarkResultFailure).GetBytes())
+		return
+	}
+
+	_ = s.Send(messages.NewMsgS2CRegisterMark(msg.PcId, clanId, version, constants.ClanMarkResultSuccess).GetBytes())
+}
+
+// handleTransferMark sends the mark of a clan. The client passes the version
+// it has cached, and if that is still current the mark itself is left out.
+func (s *accountServerSession) handleTransferMark(packet []byte) {
+	msg, err := messages.ReadMsgC2STransferMark(packet)
+	if err != nil {
+		return
+	}
+
+	mark, err := s.server.dbService.GetClanMark(msg.ClanId)
+	if err != nil {
+		return
+	}
+
+	reply := messages.NewMsgS2CTransferMark(msg.PcId, msg.ClanId, 0)
+	if mark != nil {
+		reply.MarkVersion = mark.Version
+		if mark.Version != msg.MarkVersion {
+			reply.MarkSize = uint16(copy(reply.Mark[:], mark.Mark))
+		}
+	}
+
+	_ = s.Send(reply.GetBytes())
+}
+
+// handleAskMark tells the client the version of a clan's mark, so it can tell
+// whether the copy it has cached needs to be fetched again.
+func (s *accountServerSession) handleAskMark(packet []byte) {
+	msg, err := messages.ReadMsgC2SAskMark(packet)
+	if err != nil {
+		return
+	}
+
+	version, err := s.server.dbService.GetClanMarkVersion(msg.ClanId)
+	if err != nil {
+		return
+	}
+
+	_ = s.Send(messages.NewMsgS2CAskMark(msg.PcId, msg.ClanId, version).GetBytes())
+}
+
+func isClanMarkPacket(packet []byte) bool {
+	if len(packet) < 12 {
+		return false
+	}
+
+	switch binary.LittleEndian.Uint16(packet[10:]) {
+	case protocol.C2ZRegisterMark, protocol.C2STransferMark, protocol.C2SAskMark:
+		return true
+	default:
+		return false
+	}
+}
+
+// validateClanMark checks that the mark is a bitmap of the size and format
+// clan marks are drawn in, with nothing past the pixels.
+func validateClanMark(mark []byte) error {
+	if len(mark) < bitmapFileHeaderSize+bitmapInfoHeaderSize || mark[0] != 'B' || mark[1] != 'M' {
+		return errInvalidClanMark
+	}
+
+	fileSize := binary.LittleEndian.Uint32(mark[2:])
+	pixelOffset := binary.LittleEndian.Uint32(mark[10:])
+	infoHeaderSize := binary.LittleEndian.Uint32(mark[14:])
+	width := int32(binary.LittleEndian.Uint32(mark[18:]))
+	height := int32(binary.LittleEndian.Uint32(mark[22:]))
+	planes := binary.LittleEndian.Uint16(mark[26:])
+	bitCount := binary.LittleEndian.Uint16(mark[28:])
+	compression := binary.LittleEndian.Uint32(mark[30:])
+	if int(fileSize) != len(mark) ||
+		infoHeaderSize < bitmapInfoHeaderSize ||
+		pixelOffset < bitmapFileHeaderSize+infoHeaderSize ||
+		width != clanMarkWidth ||
+		(height != clanMarkHeight && height != -clanMarkHeight) ||
+		planes != 1 ||
+		bitCount != clanMarkBitCount ||
+		compression != bitmapCompressionNone ||
+		uint64(pixelOffset)+clanMarkPixelArraySize != uint64(len(mark)) {
+		return errInvalidClanMark
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package accountserver
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages/protocol"
+)
+
+// newTestClanMark builds a valid clan mark bitmap.
+func newTestClanMark() []byte {
+	pixelOffset := bitmapFileHeaderSize + bitmapInfoHeaderSize
+	mark := make([]byte, pixelOffset+clanMarkPixelArraySize)
+	mark[0], mark[1] = 'B', 'M'
+	binary.LittleEndian.PutUint32(mark[2:], uint32(len(mark)))
+	binary.LittleEndian.PutUint32(mark[10:], uint32(pixelOffset))
+	binary.LittleEndian.PutUint32(mark[14:], bitmapInfoHeaderSize)
+	binary.LittleEndian.PutUint32(mark[18:], clanMarkWidth)
+	binary.LittleEndian.PutUint32(mark[22:], clanMarkHeight)
+	binary.LittleEndian.PutUint16(mark[26:], 1)
+	binary.LittleEndian.PutUint16(mark[28:], clanMarkBitCount)
+	return mark
+}
+
+func TestValidateClanMark(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(mark []byte) []byte
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			mutate: func(mark []byte) []byte { return mark },
+		},
+		{
+			name: "top-down",
+			mutate: func(mark []byte) []byte {
+				height := int32(-clanMarkHeight)
+				binary.LittleEndian.PutUint32(mark[22:], uint32(height))
+				return mark
+			},
+		},
+		{
+			name: "oversized",
+			mutate: func(mark []byte) []byte {
+				mark = append(mark, make([]byte, 64)...)
+				binary.LittleEndian.PutUint32(mark[2:], uint32(len(mark)))
+				return mark
+			},
+			wantErr: true,
+		},
+		{
+			name: "file size does not match",
+			mutate: func(mark []byte) []byte {
+				return append(mark, 0)
+			},
+			wantErr: true,
+		},
+		{
+			name: "truncated",
+			mutate: func(mark []byte) []byte {
+				return mark[:bitmapFileHeaderSize+bitmapInfoHeaderSize-1]
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrong bit depth",
+			mutate: func(mark []byte) []byte {
+				binary.LittleEndian.PutUint16(mark[28:], 8)
+				return mark
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrong width",
+			mutate: func(mark []byte) []byte {
+				binary.LittleEndian.PutUint32(mark[18:], clanMarkWidth*2)
+				return mark
+			},
+			wantErr: true,
+		},
+		{
+			name: "compressed",
+			mutate: func(mark []byte) []byte {
+				binary.LittleEndian.PutUint32(mark[30:], 1)
+				return mark
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad signature",
+			mutate: func(mark []byte) []byte {
+				mark[0], mark[1] = 'P', 'K'
+				return mark
+			},
+			wantErr: true,
+		},
+		{
+			name: "pixel offset inside the headers",
+			mutate: func(mark []byte) []byte {
+				binary.LittleEndian.PutUint32(mark[10:], bitmapFileHeaderSize)
+				return mark
+			},
+			wantErr: true,
+		},
+		{
+			name: "info header too small",
+			mutate: func(mark []byte) []byte {
+				binary.LittleEndian.PutUint32(mark[14:], 12)
+				return mark
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateClanMark(tt.mutate(newTestClanMark()))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateClanMark() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsClanMarkPacket(t *testing.T) {
+	tests := []struct {
+		name   string
+		packet []byte
+		want   bool
+	}{
+		{"register mark", newTestPacket(protocol.C2ZRegisterMark), true},
+		{"transfer mark", newTestPacket(protocol.C2STransferMark), true},
+		{"ask mark", newTestPacket(protocol.C2SAskMark), true},
+		{"other protocol", newTestPacket(protocol.C2SAskMark + 1), false},
+		{"too short", newTestPacket(protocol.C2SAskMark)[:11], false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isClanMarkPacket(tt.packet); got != tt.want {
+				t.Errorf("isClanMarkPacket() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func newTestPacket(proto uint16) []byte {
+	packet := make([]byte, 12)
+	binary.LittleEndian.PutUint16(packet[10:], proto)
+	return packet
+}
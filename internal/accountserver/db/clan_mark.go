@@ -0,0 +1,124 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/constants"
+)
+
+type ClanMark struct {
+	Mark    []byte `db:"mark"`
+	Version uint32 `db:"version"`
+}
+
+// GetMasterClanId returns the clan the character of the account is master
+// of, or 0 if it is not master of any.
+func (s *dbService) GetMasterClanId(accountID uint32, characterName string) (uint32, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query, args, err := psql.Select("clan_members.clan_id").
+		From("clan_members").
+		Join("characters ON characters.id = clan_members.character_id").
+		Where(sq.And{
+			sq.Eq{"characters.account_id": accountID},
+			sq.Eq{"characters.name": characterName},
+			sq.Eq{"characters.status": constants.CharacterStatusActive},
+			sq.Eq{"clan_members.rank": constants.ClanRankMaster},
+		}).
+		ToSql()
+	if err != nil {
+		s.logger.Error("Failed to build get master clan id query", shared.Field{Key: "error", Value: err})
+		return 0, err
+	}
+
+	var clanId uint32
+	err = s.db.Get(&clanId, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+
+	if err != nil {
+		s.logger.Error("Failed to execute get master clan id query", shared.Field{Key: "error", Value: err})
+		return 0, err
+	}
+
+	return clanId, nil
+}
+
+// SaveClanMark stores the mark of the clan and returns its new version,
+// which goes up by one with every upload so clients know to fetch it again.
+func (s *dbService) SaveClanMark(clanId uint32, mark []byte) (uint32, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query, args, err := psql.Insert("clan_marks").
+		Columns("clan_id", "mark").
+		Values(clanId, mark).
+		Suffix("ON CONFLICT (clan_id) DO UPDATE SET mark = EXCLUDED.mark, version = clan_marks.version + 1, updated_at = NOW() RETURNING version").
+		ToSql()
+	if err != nil {
+		s.logger.Error("Failed to build save clan mark query", shared.Field{Key: "error", Value: err})
+		return 0, err
+	}
+
+	var version uint32
+	if err := s.db.Get(&version, query, args...); err != nil {
+		s.logger.Error("Failed to execute save clan mark query", shared.Field{Key: "error", Value: err})
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// GetClanMark returns the mark of the clan, or nil if it has none.
+func (s *dbService) GetClanMark(clanId uint32) (*ClanMark, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query, args, err := psql.Select("mark", "version").
+		From("clan_marks").
+		Where(sq.Eq{"clan_id": clanId}).
+		ToSql()
+	if err != nil {
+		s.logger.Error("Failed to build get clan mark query", shared.Field{Key: "error", Value: err})
+		return nil, err
+	}
+
+	mark := &ClanMark{}
+	err = s.db.Get(mark, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		s.logger.Error("Failed to execute get clan mark query", shared.Field{Key: "error", Value: err})
+		return nil, err
+	}
+
+	return mark, nil
+}
+
+// GetClanMarkVersion returns the version of the clan's mark, or 0 if it has
+// none, without loading the mark itself.
+func (s *dbService) GetClanMarkVersion(clanId uint32) (uint32, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query, args, err := psql.Select("version").
+		From("clan_marks").
+		Where(sq.Eq{"clan_id": clanId}).
+		ToSql()
+	if err != nil {
+		s.logger.Error("Failed to build get clan mark version query", shared.Field{Key: "error", Value: err})
+		return 0, err
+	}
+
+	var version uint32
+	err = s.db.Get(&version, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+
+	if err != nil {
+		s.logger.Error("Failed to execute get clan mark version query", shared.Field{Key: "error", Value: err})
+		return 0, err
+	}
+
+	return version, nil
+}
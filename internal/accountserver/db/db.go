@@ -20,6 +20,10 @@ type DBService interface {
 	GetCharacterCount(accountID uint32) (int, error)
 	CreateCharacter(accountID uint32, name string, class byte, characterData []byte) (uint32, error)
 	DeleteCharacter(accountID uint32, name string) error
+	GetMasterClanId(accountID uint32, characterName string) (uint32, error)
+	SaveClanMark(clanId uint32, mark []byte) (uint32, error)
+	GetClanMark(clanId uint32) (*ClanMark, error)
+	GetClanMarkVersion(clanId uint32) (uint32, error)
 	GetDB() *sqlx.DB
 	Close() error
 }
@@ -131,6 +131,12 @@ func (s *accountServerSession) processPacket(packet []byte) {
 		}
 
 	default:
+		// The gate server passes clan mark packets on whatever their ctrl.
+		if isClanMarkPacket(packet) {
+			s.handleProtocolPacket(packet)
+			return
+		}
+
 		s.server.Logger.Error("Unhandled packet", shared.Field{Key: "ctrl", Value: ctrl}, shared.Field{Key: "cmd", Value: cmd})
 	}
 }
@@ -256,6 +262,12 @@ func (s *accountServerSession) handleProtocolPacket(packet []byte) {
 		s.handleCharacterDelete(packet)
 	case protocol.C2SCharacterLogin:
 		s.handleCharacterLogin(packet)
+	case protocol.C2ZRegisterMark:
+		s.handleRegisterMark(packet)
+	case protocol.C2STransferMark:
+		s.handleTransferMark(packet)
+	case protocol.C2SAskMark:
+		s.handleAskMark(packet)
 	default:
 		s.server.Logger.Error("Unhandled packet from gate server", shared.Field{Key: "protocol", Value: proto})
 	}
@@ -135,9 +135,11 @@ func (s *serverSession) processPacket(packet []byte) {
 		switch protocol {
 		case 0x1106: // Character login
 			fallthrough
+		case 0x2320: // Register Clan Mark
+			fallthrough
 		case 0x2322: // Transfer Clan Mark
 			fallthrough
-		case 0x2323: // Clan...
+		case 0x2323: // Ask Clan Mark
 			fallthrough
 		case 0xA001: // Create Character
 			fallthrough
@@ -154,9 +156,11 @@ func (s *serverSession) processPacket(packet []byte) {
 
 		s.server.crypto.Decrypt(packet)
 		switch protocol {
+		case 0x2320: // Register Clan Mark
+			fallthrough
 		case 0x2322: // Transfer Clan Mark
 			fallthrough
-		case 0x2323: // Clan...
+		case 0x2323: // Ask Clan Mark
 			_ = s.server.zoneServerClients.Send(constants.AccountServerServerId, packet)
 		}
 	}
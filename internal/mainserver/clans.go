@@ -0,0 +1,70 @@
+package mainserver
+
+import (
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/constants"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages"
+	"github.com/project-agonyl/open-agonyl-servers/internal/utils"
+)
+
+// handleJoinClan passes a clan invitation on to the zone server of the
+// invited player. Clans are kept in the database by the zone servers, so the
+// main server only relays clan packets between them.
+func (s *mainServerSession) handleJoinClan(packet []byte) {
+	msg, err := messages.ReadMsgS2MJoinClan(packet)
+	if err != nil {
+		return
+	}
+
+	requester, exists := s.server.players.Get(msg.PcId)
+	if !exists {
+		return
+	}
+
+	target, exists := s.server.players.Get(msg.TargetId)
+	if !exists || target.state != PlayerStateWorld {
+		s.server.sendToPlayer(requester, messages.NewMsgM2SAnsClan(
+			requester.pcId,
+			msg.TargetId,
+			constants.ClanResultFailure,
+			requester.gateServerId,
+		).GetBytes())
+		return
+	}
+
+	s.server.sendToPlayer(target, messages.NewMsgM2SJoinClan(
+		target.pcId,
+		requester.pcId,
+		msg.ClanId,
+		utils.ReadStringFromBytes(msg.ClanName[:]),
+		msg.RequesterCharacterId,
+		target.gateServerId,
+	).GetBytes())
+}
+
+func (s *mainServerSession) handleAnsClan(packet []byte) {
+	msg, err := messages.ReadMsgS2MAnsClan(packet)
+	if err != nil {
+		return
+	}
+
+	requester, exists := s.server.players.Get(msg.RequesterId)
+	if !exists {
+		return
+	}
+
+	s.server.sendToPlayer(requester, messages.NewMsgM2SAnsClan(requester.pcId, msg.PcId, msg.Result, requester.gateServerId).GetBytes())
+}
+
+func (s *mainServerSession) handleClanUpdate(packet []byte) {
+	msg, err := messages.ReadMsgS2MClanUpdate(packet)
+	if err != nil {
+		return
+	}
+
+	target, exists := s.server.players.Get(msg.TargetId)
+	if !exists {
+		return
+	}
+
+	s.server.sendToPlayer(target, messages.NewMsgM2SClanUpdate(target.pcId, target.gateServerId).GetBytes())
+}
@@ -0,0 +1,308 @@
+package mainserver
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/constants"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages"
+	"github.com/project-agonyl/open-agonyl-servers/internal/utils"
+)
+
+// partyMember is a party member as last reported by the zone server the
+// member is on.
+type partyMember struct {
+	pcId    uint32
+	level   uint16
+	hp      uint16
+	maxHp   uint16
+	mapId   uint16
+	mapCell uint32
+}
+
+// Party is a group of players. Parties live on the main server so that
+// members can be on different zone servers and stay in the party while
+// moving between them.
+type Party struct {
+	leaderId uint32
+	members  []*partyMember
+}
+
+func (p *Party) member(pcId uint32) *partyMember {
+	index := slices.IndexFunc(p.members, func(member *partyMember) bool {
+		return member.pcId == pcId
+	})
+	if index < 0 {
+		return nil
+	}
+
+	return p.members[index]
+}
+
+// Parties holds every party and the invitations waiting for an answer.
+// Packets from zone servers are processed concurrently, so it is all guarded
+// by one lock.
+type Parties struct {
+	mu       sync.Mutex
+	byMember map[uint32]*Party
+	invites  map[uint32]uint32
+}
+
+func NewParties() *Parties {
+	return &Parties{
+		byMember: make(map[uint32]*Party),
+		invites:  make(map[uint32]uint32),
+	}
+}
+
+func (s *mainServerSession) handleAskParty(packet []byte) {
+	msg, err := messages.ReadMsgS2MAskParty(packet)
+	if err != nil {
+		return
+	}
+
+	requester, exists := s.server.players.Get(msg.PcId)
+	if !exists {
+		return
+	}
+
+	parties := s.server.parties
+	parties.mu.Lock()
+	defer parties.mu.Unlock()
+	target, exists := s.server.players.Get(msg.TargetId)
+	party := parties.byMember[requester.pcId]
+	if !exists || target.state != PlayerStateWorld || target.pcId == requester.pcId ||
+		parties.byMember[target.pcId] != nil ||
+		(party != nil && (party.leaderId != requester.pcId || len(party.members) >= constants.PartyMaxMembers)) {
+		s.server.sendToPlayer(requester, messages.NewMsgM2SAnsParty(
+			requester.pcId,
+			msg.TargetId,
+			constants.PartyResultFailure,
+			requester.gateServerId,
+		).GetBytes())
+		return
+	}
+
+	parties.invites[target.pcId] = requester.pcId
+	s.server.sendToPlayer(target, messages.NewMsgM2SAskParty(
+		target.pcId,
+		requester.pcId,
+		requester.characterName,
+		target.gateServerId,
+	).GetBytes())
+}
+
+func (s *mainServerSession) handleAnsParty(packet []byte) {
+	msg, err := messages.ReadMsgS2MAnsParty(packet)
+	if err != nil {
+		return
+	}
+
+	parties := s.server.parties
+	parties.mu.Lock()
+	defer parties.mu.Unlock()
+	if requesterId, invited := parties.invites[msg.PcId]; !invited || requesterId != msg.RequesterId {
+		return
+	}
+
+	delete(parties.invites, msg.PcId)
+	target, targetExists := s.server.players.Get(msg.PcId)
+	requester, exists := s.server.players.Get(msg.RequesterId)
+	if !exists || !targetExists {
+		return
+	}
+
+	result := constants.PartyResultSuccess
+	party := parties.byMember[requester.pcId]
+	switch {
+	case msg.Accept == 0:
+		result = constants.PartyResultRefused
+	case parties.byMember[target.pcId] != nil:
+		result = constants.PartyResultFailure
+	case party != nil && (party.leaderId != requester.pcId || len(party.members) >= constants.PartyMaxMembers):
+		result = constants.PartyResultFailure
+	}
+
+	s.server.sendToPlayer(requester, messages.NewMsgM2SAnsParty(requester.pcId, target.pcId, result, requester.gateServerId).GetBytes())
+	s.server.sendToPlayer(target, messages.NewMsgM2SAnsParty(target.pcId, requester.pcId, result, target.gateServerId).GetBytes())
+	if result != constants.PartyResultSuccess {
+		return
+	}
+
+	if party == nil {
+		party = &Party{
+			leaderId: requester.pcId,
+			members:  []*partyMember{{pcId: requester.pcId, mapId: requester.currentMapId}},
+		}
+		parties.byMember[requester.pcId] = party
+	}
+
+	party.members = append(party.members, &partyMember{pcId: target.pcId, mapId: target.currentMapId})
+	parties.byMember[target.pcId] = party
+	s.server.sendPartyInfo(party)
+}
+
+// handleOutParty takes a member out of the party. Members can leave on their
+// own and the leader can put anyone out.
+func (s *mainServerSession) handleOutParty(packet []byte) {
+	msg, err := messages.ReadMsgS2MOutParty(packet)
+	if err != nil {
+		return
+	}
+
+	parties := s.server.parties
+	parties.mu.Lock()
+	defer parties.mu.Unlock()
+	party := parties.byMember[msg.PcId]
+	if party == nil || party.member(msg.TargetId) == nil ||
+		(msg.TargetId != msg.PcId && party.leaderId != msg.PcId) {
+		return
+	}
+
+	s.server.removePartyMember(party, msg.TargetId)
+}
+
+// handlePartyMemberUpdate records the state a zone server reports for a
+// party member and passes it on to the rest of the party. A member that has
+// just entered a zone is sent the whole party, which is how party state
+// follows a member from one zone server to another.
+func (s *mainServerSession) handlePartyMemberUpdate(packet []byte) {
+	msg, err := messages.ReadMsgS2MPartyMemberUpdate(packet)
+	if err != nil {
+		return
+	}
+
+	parties := s.server.parties
+	parties.mu.Lock()
+	defer parties.mu.Unlock()
+	party := parties.byMember[msg.PcId]
+	if party == nil {
+		return
+	}
+
+	member := party.member(msg.PcId)
+	member.level = msg.Level
+	member.hp = msg.HP
+	member.maxHp = msg.MaxHp
+	member.mapId = msg.MapId
+	member.mapCell = msg.MapCell
+	if msg.Entered != 0 {
+		if player, exists := s.server.players.Get(msg.PcId); exists {
+			s.server.sendPartyInfoTo(party, player)
+		}
+	}
+
+	update := s.server.newPartyMemberMsg(member)
+	for _, other := range party.members {
+		if other.pcId == member.pcId {
+			continue
+		}
+
+		player, exists := s.server.players.Get(other.pcId)
+		if !exists {
+			continue
+		}
+
+		s.server.sendToPlayer(player, messages.NewMsgM2SPartyMemberUpdate(player.pcId, update, player.gateServerId).GetBytes())
+	}
+}
+
+// leaveParty takes a player that is logging out out of its party and drops
+// any invitation to or from the player.
+func (s *Server) leaveParty(pcId uint32) {
+	s.parties.mu.Lock()
+	defer s.parties.mu.Unlock()
+	delete(s.parties.invites, pcId)
+	for targetId, requesterId := range s.parties.invites {
+		if requesterId == pcId {
+			delete(s.parties.invites, targetId)
+		}
+	}
+
+	if party := s.parties.byMember[pcId]; party != nil {
+		s.removePartyMember(party, pcId)
+	}
+}
+
+// removePartyMember takes the member out of the party and tells everyone
+// what is left of it. A party left with a single member is disbanded, and
+// if the leader leaves the next member leads. The parties lock must be held.
+func (s *Server) removePartyMember(party *Party, pcId uint32) {
+	party.members = slices.DeleteFunc(party.members, func(member *partyMember) bool {
+		return member.pcId == pcId
+	})
+	delete(s.parties.byMember, pcId)
+	if player, exists := s.players.Get(pcId); exists {
+		s.sendToPlayer(player, messages.NewMsgM2SPartyInfo(pcId, 0, player.gateServerId).GetBytes())
+	}
+
+	if len(party.members) < 2 {
+		for _, member := range party.members {
+			delete(s.parties.byMember, member.pcId)
+			if player, exists := s.players.Get(member.pcId); exists {
+				s.sendToPlayer(player, messages.NewMsgM2SPartyInfo(member.pcId, 0, player.gateServerId).GetBytes())
+			}
+		}
+
+		return
+	}
+
+	if party.leaderId == pcId {
+		party.leaderId = party.members[0].pcId
+	}
+
+	s.sendPartyInfo(party)
+}
+
+func (s *Server) sendPartyInfo(party *Party) {
+	for _, member := range party.members {
+		if player, exists := s.players.Get(member.pcId); exists {
+			s.sendPartyInfoTo(party, player)
+		}
+	}
+}
+
+func (s *Server) sendPartyInfoTo(party *Party, player *Player) {
+	msg := messages.NewMsgM2SPartyInfo(player.pcId, party.leaderId, player.gateServerId)
+	for i, member := range party.members {
+		if i >= len(msg.Members) {
+			break
+		}
+
+		msg.Members[i] = s.newPartyMemberMsg(member)
+		msg.MemberCount++
+	}
+
+	s.sendToPlayer(player, msg.GetBytes())
+}
+
+func (s *Server) newPartyMemberMsg(member *partyMember) messages.PartyMember {
+	msg := messages.PartyMember{
+		PcId:    member.pcId,
+		Level:   member.level,
+		HP:      member.hp,
+		MaxHp:   member.maxHp,
+		MapId:   member.mapId,
+		MapCell: member.mapCell,
+	}
+	if player, exists := s.players.Get(member.pcId); exists {
+		copy(msg.Name[:], utils.MakeFixedLengthStringBytes(player.characterName, len(msg.Name)))
+	}
+
+	return msg
+}
+
+func (s *Server) sendToPlayer(player *Player, packet []byte) {
+	if player.zone == nil {
+		return
+	}
+
+	if err := player.zone.Send(packet); err != nil {
+		s.Logger.Error("Failed to send packet to player's zone server",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.pcId},
+			shared.Field{Key: "serverId", Value: player.currentServerId},
+		)
+	}
+}
@@ -14,6 +14,7 @@ type Server struct {
 	cfg          *config.EnvVars
 	dbService    db.DBService
 	players      *Players
+	parties      *Parties
 	mapZones     *shared.SafeMap[uint16, *Zone]
 	zoneSessions *shared.SafeMap[byte, *Zone]
 }
@@ -30,6 +31,7 @@ func NewServer(cfg *config.EnvVars, db db.DBService, logger shared.Logger, playe
 		cfg:          cfg,
 		dbService:    db,
 		players:      players,
+		parties:      NewParties(),
 		mapZones:     shared.NewSafeMap[uint16, *Zone](),
 		zoneSessions: shared.NewSafeMap[byte, *Zone](),
 	}
@@ -171,7 +171,7 @@ func (s *mainServerSession) processPacket(packet []byte) {
 			zone,
 		)
 		s.server.players.Add(player)
-		loginMsg := messages.NewMsgM2SAnsCharacterLogin(msg.PcId, msg.GateServerId, mapId, 0)
+		loginMsg := messages.NewMsgM2SAnsCharacterLogin(msg.PcId, zone.serverId, mapId, msg.GateServerId)
 		_ = s.Send(loginMsg.GetBytes())
 	case protocol.S2MMapList:
 		mapCount := binary.LittleEndian.Uint16(packet[10:])
@@ -212,7 +212,7 @@ func (s *mainServerSession) processPacket(packet []byte) {
 		}
 
 		player.state = PlayerStateWorld
-		gsMsg := messages.NewMsgM2SWorldLogin(msg.PcId, characterName, player.currentMapId, msg.GateServerId)
+		gsMsg := messages.NewMsgM2SWorldLogin(msg.PcId, characterName, player.currentMapId, player.gateServerId)
 		_ = s.Send(gsMsg.GetBytes())
 	case protocol.S2MCharacterLogout:
 		msg, err := messages.ReadMsgS2MCharacterLogout(packet)
@@ -225,7 +225,107 @@ func (s *mainServerSession) processPacket(packet []byte) {
 			return
 		}
 
+		s.server.leaveParty(msg.PcId)
 		s.server.players.Remove(msg.PcId)
+	case protocol.S2MZoneChange:
+		msg, err := messages.ReadMsgS2MZoneChange(packet)
+		if err != nil {
+			return
+		}
+
+		player, exists := s.server.players.Get(msg.PcId)
+		if !exists {
+			return
+		}
+
+		zone, exists := s.server.mapZones.Get(msg.MapId)
+		if !exists {
+			ansMsg := messages.NewMsgM2SZoneChange(msg.PcId, msg.MapId, s.serverId, constants.ZoneChangeResultFailure, player.gateServerId)
+			_ = s.Send(ansMsg.GetBytes())
+			s.server.Logger.Error("Zone change target zone not found",
+				shared.Field{Key: "pcId", Value: msg.PcId},
+				shared.Field{Key: "mapId", Value: msg.MapId},
+				shared.Field{Key: "serverId", Value: s.serverId},
+			)
+			return
+		}
+
+		player.currentMapId = msg.MapId
+		player.currentServerId = zone.serverId
+		player.zone = zone
+		ansMsg := messages.NewMsgM2SZoneChange(msg.PcId, msg.MapId, zone.serverId, constants.ZoneChangeResultSuccess, player.gateServerId)
+		_ = s.Send(ansMsg.GetBytes())
+		if zone.serverId == s.serverId {
+			return
+		}
+
+		loginMsg := messages.NewMsgM2SWorldLogin(msg.PcId, player.characterName, msg.MapId, player.gateServerId)
+		if err := zone.Send(loginMsg.GetBytes()); err != nil {
+			s.server.Logger.Error("Failed to send world login to zone server",
+				shared.Field{Key: "error", Value: err},
+				shared.Field{Key: "pcId", Value: msg.PcId},
+				shared.Field{Key: "mapId", Value: msg.MapId},
+				shared.Field{Key: "serverId", Value: zone.serverId},
+			)
+		}
+	case protocol.S2MWhisper:
+		msg, err := messages.ReadMsgS2MWhisper(packet)
+		if err != nil {
+			return
+		}
+
+		targetName := utils.ReadStringFromBytes(msg.TargetName[:])
+		target, exists := s.server.players.GetByCharacterName(targetName)
+		if !exists || target.state != PlayerStateWorld {
+			errMsg := messages.NewMsgM2SError(msg.PcId, constants.ErrorCodeGenericFailure, constants.CharacterNotOnlineMsg, msg.GateServerId)
+			_ = s.Send(errMsg.GetBytes())
+			return
+		}
+
+		senderName := utils.ReadStringFromBytes(msg.SenderName[:])
+		message := utils.ReadStringFromBytes(msg.Message[:])
+		whisperMsg := messages.NewMsgM2SWhisper(target.pcId, senderName, message, target.gateServerId)
+		if err := target.zone.Send(whisperMsg.GetBytes()); err != nil {
+			s.server.Logger.Error("Failed to route whisper",
+				shared.Field{Key: "error", Value: err},
+				shared.Field{Key: "pcId", Value: target.pcId},
+				shared.Field{Key: "serverId", Value: target.currentServerId},
+			)
+		}
+	case protocol.S2MNationChat:
+		msg, err := messages.ReadMsgS2MNationChat(packet)
+		if err != nil {
+			return
+		}
+
+		senderName := utils.ReadStringFromBytes(msg.SenderName[:])
+		message := utils.ReadStringFromBytes(msg.Message[:])
+		chatMsg := messages.NewMsgM2SNationChat(msg.PcId, msg.Nation, senderName, message, msg.GateServerId).GetBytes()
+		s.server.zoneSessions.Range(func(serverId byte, zone *Zone) bool {
+			if err := zone.Send(chatMsg); err != nil {
+				s.server.Logger.Error("Failed to route nation chat",
+					shared.Field{Key: "error", Value: err},
+					shared.Field{Key: "pcId", Value: msg.PcId},
+					shared.Field{Key: "serverId", Value: serverId},
+				)
+			}
+
+			return true
+		})
+	case protocol.S2MAskParty:
+		s.handleAskParty(packet)
+	case protocol.S2MAnsParty:
+		s.handleAnsParty(packet)
+	case protocol.S2MOutParty:
+		s.handleOutParty(packet)
+	case protocol.S2MPartyMemberUpdate:
+		s.handlePartyMemberUpdate(packet)
+	case protocol.S2MJoinClan:
+		s.handleJoinClan(packet)
+	case protocol.S2MAnsClan:
+		s.handleAnsClan(packet)
+	case protocol.S2MClanUpdate:
+		s.handleClanUpdate(packet)
 	default:
 		s.server.Logger.Info("Unhandled packet",
 			shared.Field{Key: "packet", Value: packet},
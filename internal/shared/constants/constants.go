@@ -18,6 +18,8 @@ const InvalidCharacterMsg = "Invalid character."
 
 const ThereWasAnIssueLoggingInMsg = "There was an issue logging in."
 
+const CharacterNotOnlineMsg = "Character is not online."
+
 const (
 	AccountStatusActive              = "active"
 	AccountStatusInactive            = "inactive"
@@ -32,3 +34,46 @@ const (
 	CharacterStatusLocked  = "locked"
 	CharacterStatusDeleted = "deleted"
 )
+
+const (
+	ClassWarrior    byte = 0x00
+	ClassHolyKnight byte = 0x01
+	ClassMage       byte = 0x02
+	ClassArcher     byte = 0x03
+)
+
+const (
+	ZoneChangeResultSuccess byte = 0x00
+	ZoneChangeResultFailure byte = 0x01
+)
+
+const PartyMaxMembers = 8
+
+const (
+	PartyResultSuccess byte = 0x00
+	PartyResultRefused byte = 0x01
+	PartyResultFailure byte = 0x02
+)
+
+const ClanMaxMembers = 50
+
+const (
+	ClanRankNone    byte = 0x00
+	ClanRankMember  byte = 0x01
+	ClanRankOfficer byte = 0x02
+	ClanRankMaster  byte = 0x03
+)
+
+const (
+	ClanResultSuccess   byte = 0x00
+	ClanResultRefused   byte = 0x01
+	ClanResultFailure   byte = 0x02
+	ClanResultNameTaken byte = 0x03
+	ClanResultFull      byte = 0x04
+)
+
+const (
+	ClanMarkResultSuccess byte = 0x00
+	ClanMarkResultFailure byte = 0x01
+	ClanMarkResultInvalid byte = 0x02
+)
@@ -0,0 +1,69 @@
+package data
+
+// ClassStats holds what the stats of a class are worked out from. Base
+// stats are listed in the order the client numbers them: Strength,
+// Intelligence, Dexterity, Vitality and Mana. Points cannot be retrieved
+// below the floors, which are the stats new characters of the class start
+// with, nor allotted above the caps. Retrieving a point costs
+// RetrievePointCost Woonz.
+//
+// Every point of Vitality or Mana adds HPPerVitality or MPPerMana to the HP
+// or MP the class can have on top of its capacity. Weapons add to magic
+// attack instead of hit attack for classes with WeaponMagicAttack set.
+//
+// Each level gained raises the HP and MP capacity by HPPerLevel and
+// MPPerLevel and gives StatPointsPerLevel points to allot.
+type ClassStats struct {
+	Floors             [5]uint16   `json:"floors"`
+	Caps               [5]uint16   `json:"caps"`
+	RetrievePointCost  uint32      `json:"retrieve_point_cost"`
+	HPPerVitality      uint16      `json:"hp_per_vitality"`
+	MPPerMana          uint16      `json:"mp_per_mana"`
+	HitAttack          StatFormula `json:"hit_attack"`
+	MagicAttack        StatFormula `json:"magic_attack"`
+	Defense            StatFormula `json:"defense"`
+	WeaponMagicAttack  bool        `json:"weapon_magic_attack"`
+	HPPerLevel         uint16      `json:"hp_per_level"`
+	MPPerLevel         uint16      `json:"mp_per_level"`
+	StatPointsPerLevel uint16      `json:"stat_points_per_level"`
+}
+
+// StatFormula works a calculated stat out as the sum of each base stat and
+// the level divided by its divisor. A divisor of 0 leaves the term out.
+type StatFormula struct {
+	Divisors     [5]uint16 `json:"divisors"`
+	LevelDivisor uint16    `json:"level_divisor"`
+}
+
+// Apply works the stat out from the base stats and level.
+func (f StatFormula) Apply(baseStats [5]uint16, level uint16) int {
+	value := 0
+	for i, divisor := range f.Divisors {
+		if divisor != 0 {
+			value += int(baseStats[i]) / int(divisor)
+		}
+	}
+
+	if f.LevelDivisor != 0 {
+		value += int(level) / int(f.LevelDivisor)
+	}
+
+	return value
+}
+
+// ClassStatTable holds the stat bounds keyed by class.
+type ClassStatTable map[byte]ClassStats
+
+func (t ClassStatTable) Get(class byte) (ClassStats, bool) {
+	classStats, exists := t[class]
+	return classStats, exists
+}
+
+func LoadClassStatTable(classStatTableFilePath string) (ClassStatTable, error) {
+	classStatTable := ClassStatTable{}
+	if err := loadJSONFile(classStatTableFilePath, &classStatTable); err != nil {
+		return nil, err
+	}
+
+	return classStatTable, nil
+}
@@ -0,0 +1,52 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadClassStatTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			json: `{"0": {"floors": [30, 0, 16, 30, 20], "caps": [200, 0, 200, 200, 200], "retrieve_point_cost": 100}}`,
+		},
+		{
+			name:    "misspelled key",
+			json:    `{"0": {"floors": [30, 0, 16, 30, 20], "cap": [200, 0, 200, 200, 200]}}`,
+			wantErr: true,
+		},
+		{
+			name:    "class that is not a number",
+			json:    `{"warrior": {"floors": [30, 0, 16, 30, 20]}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "class_stat.json")
+			if err := os.WriteFile(filePath, []byte(tt.json), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			table, err := LoadClassStatTable(filePath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadClassStatTable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			classStats, ok := table.Get(0)
+			if !ok || classStats.Caps[0] != 200 || classStats.RetrievePointCost != 100 {
+				t.Errorf("Get(0) = %+v, %v", classStats, ok)
+			}
+		})
+	}
+}
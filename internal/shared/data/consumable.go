@@ -0,0 +1,28 @@
+package data
+
+// Consumable is what using an item does. Items sharing a cooldown group
+// cannot be used again until the cooldown of the last one used has passed.
+type Consumable struct {
+	HP            uint16 `json:"hp"`
+	MP            uint16 `json:"mp"`
+	Exp           uint32 `json:"exp"`
+	CooldownGroup byte   `json:"cooldown_group"`
+	CooldownMs    uint32 `json:"cooldown_ms"`
+}
+
+// IsPotion reports whether the consumable restores HP or MP.
+func (c *Consumable) IsPotion() bool {
+	return c.HP > 0 || c.MP > 0
+}
+
+// ConsumableTable holds the consumables keyed by item code.
+type ConsumableTable map[uint32]Consumable
+
+func LoadConsumableTable(consumableTableFilePath string) (ConsumableTable, error) {
+	consumableTable := ConsumableTable{}
+	if err := loadJSONFile(consumableTableFilePath, &consumableTable); err != nil {
+		return nil, err
+	}
+
+	return consumableTable, nil
+}
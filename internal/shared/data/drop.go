@@ -0,0 +1,22 @@
+package data
+
+// DropChanceScale is what DropItem.Chance is out of.
+const DropChanceScale = 10000
+
+type DropItem struct {
+	ItemCode   uint32 `json:"item_code"`
+	ItemOption uint32 `json:"item_option"`
+	Chance     uint32 `json:"chance"`
+}
+
+// DropTable holds the items each monster can drop, keyed by NPC id.
+type DropTable map[uint16][]DropItem
+
+func LoadDropTable(dropTableFilePath string) (DropTable, error) {
+	dropTable := DropTable{}
+	if err := loadJSONFile(dropTableFilePath, &dropTable); err != nil {
+		return nil, err
+	}
+
+	return dropTable, nil
+}
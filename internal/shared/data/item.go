@@ -21,6 +21,7 @@ type IT0Level struct {
 }
 
 type IT0Property struct {
+	Class  uint16
 	Levels []IT0Level
 }
 
@@ -141,7 +142,7 @@ func LoadIT0Items(it0FilePath string, it0ExFilePath string) ([]Item, error) {
 			return nil, err
 		}
 
-		it0property := &IT0Property{}
+		it0property := &IT0Property{Class: it0Raw.Type}
 		it0property.Levels = make([]IT0Level, 10)
 		for j, property := range it0Raw.Levels {
 			it0property.Levels[j] = IT0Level{
@@ -154,6 +155,7 @@ func LoadIT0Items(it0FilePath string, it0ExFilePath string) ([]Item, error) {
 				AdditionalAttribute: property.AdditionalAttribute,
 				RedOption:           property.RedOption,
 				GreyOption:          property.GreyOption,
+				BlueOption:          property.BlueOption,
 			}
 		}
 
@@ -192,6 +194,7 @@ func LoadIT0Items(it0FilePath string, it0ExFilePath string) ([]Item, error) {
 					AdditionalAttribute: property.AdditionalAttribute,
 					RedOption:           property.RedOption,
 					GreyOption:          property.GreyOption,
+					BlueOption:          property.BlueOption,
 				})
 		}
 	}
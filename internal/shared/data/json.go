@@ -0,0 +1,24 @@
+package data
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// loadJSONFile decodes the JSON file into v. Keys v has no field for are an
+// error instead of being dropped, so a misspelled key in a data file is
+// reported when the file is loaded rather than quietly leaving a value zero.
+func loadJSONFile(filePath string, v any) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
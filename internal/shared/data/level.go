@@ -0,0 +1,41 @@
+package data
+
+import "fmt"
+
+// LevelTable holds the total experience needed to reach each level, starting
+// with level 1. The highest level a character can reach is the length of
+// the table.
+type LevelTable []uint32
+
+// MaxLevel returns the highest level in the table.
+func (t LevelTable) MaxLevel() uint16 {
+	return uint16(len(t))
+}
+
+// Exp returns the total experience needed to reach the level.
+func (t LevelTable) Exp(level uint16) (uint32, bool) {
+	if level == 0 || int(level) > len(t) {
+		return 0, false
+	}
+
+	return t[level-1], true
+}
+
+func LoadLevelTable(levelTableFilePath string) (LevelTable, error) {
+	levelTable := LevelTable{}
+	if err := loadJSONFile(levelTableFilePath, &levelTable); err != nil {
+		return nil, err
+	}
+
+	if len(levelTable) > 0xFFFF {
+		return nil, fmt.Errorf("level table has %d levels", len(levelTable))
+	}
+
+	for i := 1; i < len(levelTable); i++ {
+		if levelTable[i] < levelTable[i-1] {
+			return nil, fmt.Errorf("level %d needs less experience than level %d", i+1, i)
+		}
+	}
+
+	return levelTable, nil
+}
@@ -0,0 +1,61 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadLevelTable(t *testing.T) {
+	tests := []struct {
+		name         string
+		json         string
+		wantErr      bool
+		wantMaxLevel uint16
+	}{
+		{
+			name:         "valid",
+			json:         `[0, 100, 300, 600]`,
+			wantMaxLevel: 4,
+		},
+		{
+			name:    "experience goes down",
+			json:    `[0, 300, 100]`,
+			wantErr: true,
+		},
+		{
+			name:    "not a list",
+			json:    `{"1": 0}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "level.json")
+			if err := os.WriteFile(filePath, []byte(tt.json), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			table, err := LoadLevelTable(filePath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadLevelTable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if got := table.MaxLevel(); got != tt.wantMaxLevel {
+				t.Errorf("MaxLevel() = %d, want %d", got, tt.wantMaxLevel)
+			}
+
+			if exp, ok := table.Exp(2); !ok || exp != 100 {
+				t.Errorf("Exp(2) = %d, %v, want 100, true", exp, ok)
+			}
+
+			if _, ok := table.Exp(tt.wantMaxLevel + 1); ok {
+				t.Errorf("Exp(%d) found a level past the table", tt.wantMaxLevel+1)
+			}
+		})
+	}
+}
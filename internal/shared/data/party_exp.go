@@ -0,0 +1,43 @@
+package data
+
+import "fmt"
+
+// PartyExpTable holds how the experience for a kill is shared among the
+// members of the killer's party. A member's share is their level times the
+// percent of the first band they are within MaxDistance cells of the killer
+// for. Members further away than every band get no share.
+type PartyExpTable struct {
+	Bands []PartyExpBand `json:"bands"`
+}
+
+type PartyExpBand struct {
+	MaxDistance int    `json:"max_distance"`
+	Percent     uint32 `json:"percent"`
+}
+
+// Percent returns the percent of a share a member the distance away from the
+// killer gets, or false if they are out of range.
+func (t PartyExpTable) Percent(distance int) (uint32, bool) {
+	for _, band := range t.Bands {
+		if distance <= band.MaxDistance {
+			return band.Percent, true
+		}
+	}
+
+	return 0, false
+}
+
+func LoadPartyExpTable(partyExpTableFilePath string) (PartyExpTable, error) {
+	partyExpTable := PartyExpTable{}
+	if err := loadJSONFile(partyExpTableFilePath, &partyExpTable); err != nil {
+		return PartyExpTable{}, err
+	}
+
+	for i := 1; i < len(partyExpTable.Bands); i++ {
+		if partyExpTable.Bands[i].MaxDistance <= partyExpTable.Bands[i-1].MaxDistance {
+			return PartyExpTable{}, fmt.Errorf("party exp band %d does not reach further than band %d", i, i-1)
+		}
+	}
+
+	return partyExpTable, nil
+}
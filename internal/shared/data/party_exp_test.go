@@ -0,0 +1,63 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadPartyExpTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			json: `{"bands": [{"max_distance": 5, "percent": 100}, {"max_distance": 15, "percent": 50}]}`,
+		},
+		{
+			name:    "bands out of order",
+			json:    `{"bands": [{"max_distance": 15, "percent": 50}, {"max_distance": 5, "percent": 100}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "misspelled key",
+			json:    `{"bands": [{"distance": 5, "percent": 100}]}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "party_exp.json")
+			if err := os.WriteFile(filePath, []byte(tt.json), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			table, err := LoadPartyExpTable(filePath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadPartyExpTable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			for _, c := range []struct {
+				distance    int
+				wantPercent uint32
+				wantOk      bool
+			}{
+				{0, 100, true},
+				{5, 100, true},
+				{6, 50, true},
+				{15, 50, true},
+				{16, 0, false},
+			} {
+				if percent, ok := table.Percent(c.distance); percent != c.wantPercent || ok != c.wantOk {
+					t.Errorf("Percent(%d) = %d, %v, want %d, %v", c.distance, percent, ok, c.wantPercent, c.wantOk)
+				}
+			}
+		})
+	}
+}
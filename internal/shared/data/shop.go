@@ -0,0 +1,69 @@
+package data
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type ShopItem struct {
+	ItemCode   uint32 `json:"item_code"`
+	ItemOption uint32 `json:"item_option"`
+}
+
+// ShopData holds what a merchant sells, and how much of an item's NPC price
+// it pays for items sold to it, in percent. A merchant with no sell percent
+// does not buy items.
+type ShopData struct {
+	Items       []ShopItem `json:"items"`
+	SellPercent uint32     `json:"sell_percent"`
+}
+
+// SellPrice returns what the merchant pays for an item with the NPC price.
+func (s *ShopData) SellPrice(npcPrice uint32) uint32 {
+	return uint32(min(uint64(npcPrice)*uint64(s.SellPercent)/100, math.MaxUint32))
+}
+
+func LoadShopData(shopFilePath string) (*ShopData, error) {
+	shopData := ShopData{}
+	if err := loadJSONFile(shopFilePath, &shopData); err != nil {
+		return nil, err
+	}
+
+	return &shopData, nil
+}
+
+// LoadShops loads every <npc id>.json shop file in the directory, keyed by
+// NPC id. The names of the JSON files that are not named after an NPC id are
+// returned so that they can be reported.
+func LoadShops(shopDirPath string) (map[uint16]*ShopData, []string, error) {
+	entries, err := os.ReadDir(shopDirPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	shops := make(map[uint16]*ShopData)
+	var skipped []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		npcId, err := strconv.ParseUint(strings.TrimSuffix(entry.Name(), ".json"), 10, 16)
+		if err != nil {
+			skipped = append(skipped, entry.Name())
+			continue
+		}
+
+		shopData, err := LoadShopData(filepath.Join(shopDirPath, entry.Name()))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		shops[uint16(npcId)] = shopData
+	}
+
+	return shops, skipped, nil
+}
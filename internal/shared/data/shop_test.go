@@ -0,0 +1,56 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestLoadShops(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"123.json":      `{"items": [{"item_code": 1, "item_option": 0}], "sell_percent": 50}`,
+		"merchant.json": `{"items": []}`,
+		"notes.txt":     `not a shop`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	shops, skipped, err := LoadShops(dir)
+	if err != nil {
+		t.Fatalf("LoadShops() error = %v", err)
+	}
+
+	if len(shops) != 1 || shops[123] == nil || len(shops[123].Items) != 1 {
+		t.Errorf("shops = %v, want the shop of NPC 123", shops)
+	}
+
+	if !slices.Equal(skipped, []string{"merchant.json"}) {
+		t.Errorf("skipped = %v, want [merchant.json]", skipped)
+	}
+}
+
+func TestShopSellPrice(t *testing.T) {
+	tests := []struct {
+		name        string
+		sellPercent uint32
+		npcPrice    uint32
+		want        uint32
+	}{
+		{"half", 50, 1001, 500},
+		{"does not buy", 0, 1000, 0},
+		{"capped", 200, 0xFFFFFFFF, 0xFFFFFFFF},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shop := &ShopData{SellPercent: tt.sellPercent}
+			if got := shop.SellPrice(tt.npcPrice); got != tt.want {
+				t.Errorf("SellPrice(%d) = %d, want %d", tt.npcPrice, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package data
+
+import (
+	"slices"
+)
+
+// SkillLevel is what a skill does at one of its levels. Active skills cost
+// MP and hit a single target for a percentage of a normal attack. Passive
+// skills are bought with Lore and add their bonuses to the stats.
+type SkillLevel struct {
+	MPCost        uint16 `json:"mp_cost"`
+	CooldownMs    uint32 `json:"cooldown_ms"`
+	Range         byte   `json:"range"`
+	DamagePercent uint16 `json:"damage_percent"`
+	LoreCost      uint32 `json:"lore_cost"`
+	RequiredLevel uint16 `json:"required_level"`
+	Attack        uint16 `json:"attack"`
+	Defense       uint16 `json:"defense"`
+	HP            uint16 `json:"hp"`
+	MP            uint16 `json:"mp"`
+}
+
+type SkillData struct {
+	Passive bool         `json:"passive"`
+	Books   []uint32     `json:"books"`
+	Levels  []SkillLevel `json:"levels"`
+}
+
+// Level returns the data of the skill at the given level, which starts at 1.
+func (s *SkillData) Level(level byte) (SkillLevel, bool) {
+	if level == 0 || int(level) > len(s.Levels) {
+		return SkillLevel{}, false
+	}
+
+	return s.Levels[level-1], true
+}
+
+// SkillTable holds the skills of each class keyed by class and skill id.
+type SkillTable map[byte]map[byte]SkillData
+
+func (t SkillTable) Get(class byte, skillId byte) (SkillData, bool) {
+	skill, exists := t[class][skillId]
+	return skill, exists
+}
+
+// FindByBook returns the id of the skill of the class taught by the book.
+func (t SkillTable) FindByBook(class byte, itemCode uint32) (byte, bool) {
+	for skillId, skill := range t[class] {
+		if slices.Contains(skill.Books, itemCode) {
+			return skillId, true
+		}
+	}
+
+	return 0, false
+}
+
+func LoadSkillTable(skillTableFilePath string) (SkillTable, error) {
+	skillTable := SkillTable{}
+	if err := loadJSONFile(skillTableFilePath, &skillTable); err != nil {
+		return nil, err
+	}
+
+	return skillTable, nil
+}
@@ -214,3 +214,145 @@ func ReadMsgS2MCharacterLogin(packet []byte) (*MsgS2MCharacterLogin, error) {
 
 	return &msg, nil
 }
+
+type MsgS2CRegisterMark struct {
+	MsgHead
+	ClanId      uint32
+	MarkVersion uint32
+	Result      byte
+}
+
+func (msg *MsgS2CRegisterMark) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CRegisterMark) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CRegisterMark) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CRegisterMark(pcId uint32, clanId uint32, markVersion uint32, result byte) *MsgS2CRegisterMark {
+	msg := MsgS2CRegisterMark{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CRegisterMark,
+		},
+		ClanId:      clanId,
+		MarkVersion: markVersion,
+		Result:      result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CRegisterMark(packet []byte) (*MsgS2CRegisterMark, error) {
+	var msg MsgS2CRegisterMark
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CTransferMark struct {
+	MsgHead
+	ClanId      uint32
+	MarkVersion uint32
+	MarkSize    uint16
+	Mark        [0x400]byte
+}
+
+func (msg *MsgS2CTransferMark) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CTransferMark) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CTransferMark) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CTransferMark(pcId uint32, clanId uint32, markVersion uint32) *MsgS2CTransferMark {
+	msg := MsgS2CTransferMark{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CTransferMark,
+		},
+		ClanId:      clanId,
+		MarkVersion: markVersion,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CTransferMark(packet []byte) (*MsgS2CTransferMark, error) {
+	var msg MsgS2CTransferMark
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CAskMark struct {
+	MsgHead
+	ClanId      uint32
+	MarkVersion uint32
+}
+
+func (msg *MsgS2CAskMark) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CAskMark) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CAskMark) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CAskMark(pcId uint32, clanId uint32, markVersion uint32) *MsgS2CAskMark {
+	msg := MsgS2CAskMark{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CAskMark,
+		},
+		ClanId:      clanId,
+		MarkVersion: markVersion,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CAskMark(packet []byte) (*MsgS2CAskMark, error) {
+	var msg MsgS2CAskMark
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
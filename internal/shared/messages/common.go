@@ -184,6 +184,24 @@ type SkillInfo struct {
 	LevelThreeSkills uint32
 }
 
+type PartyMember struct {
+	PcId    uint32
+	Name    [0x15]byte
+	Level   uint16
+	HP      uint16
+	MaxHp   uint16
+	MapId   uint16
+	MapCell uint32
+}
+
+type ClanMember struct {
+	PcId  uint32
+	Name  [0x15]byte
+	Level uint16
+	Class byte
+	Rank  byte
+}
+
 type SocialInfo struct {
 	KHRank uint32
 	KHId   uint32
@@ -3,6 +3,7 @@ package messages
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 
 	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages/protocol"
 	"github.com/project-agonyl/open-agonyl-servers/internal/utils"
@@ -268,3 +269,2540 @@ func ReadMsgC2SWorldLogin(packet []byte) (*MsgC2SWorldLogin, error) {
 
 	return &msg, nil
 }
+
+type MsgC2SAskMove struct {
+	MsgHead
+	StartCell  uint32
+	TargetCell uint32
+}
+
+func (msg *MsgC2SAskMove) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SAskMove) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SAskMove) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SAskMove(pcId uint32, startCell uint32, targetCell uint32) *MsgC2SAskMove {
+	msg := MsgC2SAskMove{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SAskMove,
+		},
+		StartCell:  startCell,
+		TargetCell: targetCell,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SAskMove(packet []byte) (*MsgC2SAskMove, error) {
+	var msg MsgC2SAskMove
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SPcMove struct {
+	MsgHead
+	Cell uint32
+	Stop byte
+}
+
+func (msg *MsgC2SPcMove) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SPcMove) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SPcMove) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SPcMove(pcId uint32, cell uint32, stop byte) *MsgC2SPcMove {
+	msg := MsgC2SPcMove{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SPcMove,
+		},
+		Cell: cell,
+		Stop: stop,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SPcMove(packet []byte) (*MsgC2SPcMove, error) {
+	var msg MsgC2SPcMove
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SAskHsMove struct {
+	MsgHead
+	HsId       uint32
+	StartCell  uint32
+	TargetCell uint32
+}
+
+func (msg *MsgC2SAskHsMove) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SAskHsMove) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SAskHsMove) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SAskHsMove(pcId uint32, hsId uint32, startCell uint32, targetCell uint32) *MsgC2SAskHsMove {
+	msg := MsgC2SAskHsMove{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SAskHsMove,
+		},
+		HsId:       hsId,
+		StartCell:  startCell,
+		TargetCell: targetCell,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SAskHsMove(packet []byte) (*MsgC2SAskHsMove, error) {
+	var msg MsgC2SAskHsMove
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SHsMove struct {
+	MsgHead
+	HsId uint32
+	Cell uint32
+	Stop byte
+}
+
+func (msg *MsgC2SHsMove) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SHsMove) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SHsMove) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SHsMove(pcId uint32, hsId uint32, cell uint32, stop byte) *MsgC2SHsMove {
+	msg := MsgC2SHsMove{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SHsMove,
+		},
+		HsId: hsId,
+		Cell: cell,
+		Stop: stop,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SHsMove(packet []byte) (*MsgC2SHsMove, error) {
+	var msg MsgC2SHsMove
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SAskAttack struct {
+	MsgHead
+	TargetId uint32
+}
+
+func (msg *MsgC2SAskAttack) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SAskAttack) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SAskAttack) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SAskAttack(pcId uint32, targetId uint32) *MsgC2SAskAttack {
+	msg := MsgC2SAskAttack{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SAskAttack,
+		},
+		TargetId: targetId,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SAskAttack(packet []byte) (*MsgC2SAskAttack, error) {
+	var msg MsgC2SAskAttack
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SHsAskAttack struct {
+	MsgHead
+	HsId     uint32
+	TargetId uint32
+}
+
+func (msg *MsgC2SHsAskAttack) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SHsAskAttack) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SHsAskAttack) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SHsAskAttack(pcId uint32, hsId uint32, targetId uint32) *MsgC2SHsAskAttack {
+	msg := MsgC2SHsAskAttack{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SHsAskAttack,
+		},
+		HsId:     hsId,
+		TargetId: targetId,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SHsAskAttack(packet []byte) (*MsgC2SHsAskAttack, error) {
+	var msg MsgC2SHsAskAttack
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SWarp struct {
+	MsgHead
+	WarpIndex byte
+}
+
+func (msg *MsgC2SWarp) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SWarp) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SWarp) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SWarp(pcId uint32, warpIndex byte) *MsgC2SWarp {
+	msg := MsgC2SWarp{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SWarp,
+		},
+		WarpIndex: warpIndex,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SWarp(packet []byte) (*MsgC2SWarp, error) {
+	var msg MsgC2SWarp
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SAskWarpZ2B struct {
+	MsgHead
+	MapId uint16
+}
+
+func (msg *MsgC2SAskWarpZ2B) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SAskWarpZ2B) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SAskWarpZ2B) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SAskWarpZ2B(pcId uint32, mapId uint16) *MsgC2SAskWarpZ2B {
+	msg := MsgC2SAskWarpZ2B{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SAskWarpZ2B,
+		},
+		MapId: mapId,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SAskWarpZ2B(packet []byte) (*MsgC2SAskWarpZ2B, error) {
+	var msg MsgC2SAskWarpZ2B
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SAskWarpB2Z struct {
+	MsgHead
+	MapId uint16
+}
+
+func (msg *MsgC2SAskWarpB2Z) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SAskWarpB2Z) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SAskWarpB2Z) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SAskWarpB2Z(pcId uint32, mapId uint16) *MsgC2SAskWarpB2Z {
+	msg := MsgC2SAskWarpB2Z{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SAskWarpB2Z,
+		},
+		MapId: mapId,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SAskWarpB2Z(packet []byte) (*MsgC2SAskWarpB2Z, error) {
+	var msg MsgC2SAskWarpB2Z
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SSay struct {
+	MsgHead
+	ChatType   byte
+	TargetName [0x15]byte
+	Message    [0x64]byte
+}
+
+func (msg *MsgC2SSay) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SSay) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SSay) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SSay(pcId uint32, chatType byte, targetName string, message string) *MsgC2SSay {
+	msg := MsgC2SSay{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SSay,
+		},
+		ChatType: chatType,
+	}
+
+	copy(msg.TargetName[:], utils.MakeFixedLengthStringBytes(targetName, 0x15))
+	copy(msg.Message[:], utils.MakeFixedLengthStringBytes(message, 0x64))
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SSay(packet []byte) (*MsgC2SSay, error) {
+	var msg MsgC2SSay
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SChatWindowOpt struct {
+	MsgHead
+	Option uint32
+}
+
+func (msg *MsgC2SChatWindowOpt) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SChatWindowOpt) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SChatWindowOpt) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SChatWindowOpt(pcId uint32, option uint32) *MsgC2SChatWindowOpt {
+	msg := MsgC2SChatWindowOpt{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SChatWindowOpt,
+		},
+		Option: option,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SChatWindowOpt(packet []byte) (*MsgC2SChatWindowOpt, error) {
+	var msg MsgC2SChatWindowOpt
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SMoveItem struct {
+	MsgHead
+	FromSlot byte
+	ToSlot   byte
+}
+
+func (msg *MsgC2SMoveItem) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SMoveItem) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SMoveItem) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SMoveItem(pcId uint32, fromSlot byte, toSlot byte) *MsgC2SMoveItem {
+	msg := MsgC2SMoveItem{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SMoveItem,
+		},
+		FromSlot: fromSlot,
+		ToSlot:   toSlot,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SMoveItem(packet []byte) (*MsgC2SMoveItem, error) {
+	var msg MsgC2SMoveItem
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SWearItem struct {
+	MsgHead
+	Slot byte
+}
+
+func (msg *MsgC2SWearItem) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SWearItem) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SWearItem) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SWearItem(pcId uint32, slot byte) *MsgC2SWearItem {
+	msg := MsgC2SWearItem{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SWearItem,
+		},
+		Slot: slot,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SWearItem(packet []byte) (*MsgC2SWearItem, error) {
+	var msg MsgC2SWearItem
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SStripItem struct {
+	MsgHead
+	WearIndex byte
+}
+
+func (msg *MsgC2SStripItem) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SStripItem) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SStripItem) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SStripItem(pcId uint32, wearIndex byte) *MsgC2SStripItem {
+	msg := MsgC2SStripItem{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SStripItem,
+		},
+		WearIndex: wearIndex,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SStripItem(packet []byte) (*MsgC2SStripItem, error) {
+	var msg MsgC2SStripItem
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SHsWearItem struct {
+	MsgHead
+	HsId uint32
+	Slot byte
+}
+
+func (msg *MsgC2SHsWearItem) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SHsWearItem) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SHsWearItem) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SHsWearItem(pcId uint32, hsId uint32, slot byte) *MsgC2SHsWearItem {
+	msg := MsgC2SHsWearItem{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SHsWearItem,
+		},
+		HsId: hsId,
+		Slot: slot,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SHsWearItem(packet []byte) (*MsgC2SHsWearItem, error) {
+	var msg MsgC2SHsWearItem
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SHsStripItem struct {
+	MsgHead
+	HsId      uint32
+	WearIndex byte
+}
+
+func (msg *MsgC2SHsStripItem) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SHsStripItem) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SHsStripItem) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SHsStripItem(pcId uint32, hsId uint32, wearIndex byte) *MsgC2SHsStripItem {
+	msg := MsgC2SHsStripItem{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SHsStripItem,
+		},
+		HsId:      hsId,
+		WearIndex: wearIndex,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SHsStripItem(packet []byte) (*MsgC2SHsStripItem, error) {
+	var msg MsgC2SHsStripItem
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SPickupItem struct {
+	MsgHead
+	ItemId uint32
+}
+
+func (msg *MsgC2SPickupItem) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SPickupItem) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SPickupItem) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SPickupItem(pcId uint32, itemId uint32) *MsgC2SPickupItem {
+	msg := MsgC2SPickupItem{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SPickupItem,
+		},
+		ItemId: itemId,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SPickupItem(packet []byte) (*MsgC2SPickupItem, error) {
+	var msg MsgC2SPickupItem
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SDropItem struct {
+	MsgHead
+	Slot byte
+}
+
+func (msg *MsgC2SDropItem) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SDropItem) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SDropItem) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SDropItem(pcId uint32, slot byte) *MsgC2SDropItem {
+	msg := MsgC2SDropItem{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SDropItem,
+		},
+		Slot: slot,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SDropItem(packet []byte) (*MsgC2SDropItem, error) {
+	var msg MsgC2SDropItem
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SBuyItem struct {
+	MsgHead
+	NpcId     uint32
+	ItemIndex byte
+}
+
+func (msg *MsgC2SBuyItem) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SBuyItem) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SBuyItem) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SBuyItem(pcId uint32, npcId uint32, itemIndex byte) *MsgC2SBuyItem {
+	msg := MsgC2SBuyItem{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SBuyItem,
+		},
+		NpcId:     npcId,
+		ItemIndex: itemIndex,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SBuyItem(packet []byte) (*MsgC2SBuyItem, error) {
+	var msg MsgC2SBuyItem
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SSellItem struct {
+	MsgHead
+	NpcId uint32
+	Slot  byte
+}
+
+func (msg *MsgC2SSellItem) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SSellItem) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SSellItem) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SSellItem(pcId uint32, npcId uint32, slot byte) *MsgC2SSellItem {
+	msg := MsgC2SSellItem{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SSellItem,
+		},
+		NpcId: npcId,
+		Slot:  slot,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SSellItem(packet []byte) (*MsgC2SSellItem, error) {
+	var msg MsgC2SSellItem
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SAskDeal struct {
+	MsgHead
+	TargetId uint32
+}
+
+func (msg *MsgC2SAskDeal) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SAskDeal) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SAskDeal) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SAskDeal(pcId uint32, targetId uint32) *MsgC2SAskDeal {
+	msg := MsgC2SAskDeal{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SAskDeal,
+		},
+		TargetId: targetId,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SAskDeal(packet []byte) (*MsgC2SAskDeal, error) {
+	var msg MsgC2SAskDeal
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SAnsDeal struct {
+	MsgHead
+	RequesterId uint32
+	Accept      byte
+}
+
+func (msg *MsgC2SAnsDeal) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SAnsDeal) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SAnsDeal) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SAnsDeal(pcId uint32, requesterId uint32, accept byte) *MsgC2SAnsDeal {
+	msg := MsgC2SAnsDeal{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SAnsDeal,
+		},
+		RequesterId: requesterId,
+		Accept:      accept,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SAnsDeal(packet []byte) (*MsgC2SAnsDeal, error) {
+	var msg MsgC2SAnsDeal
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SPutInItem struct {
+	MsgHead
+	Slot byte
+}
+
+func (msg *MsgC2SPutInItem) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SPutInItem) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SPutInItem) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SPutInItem(pcId uint32, slot byte) *MsgC2SPutInItem {
+	msg := MsgC2SPutInItem{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SPutInItem,
+		},
+		Slot: slot,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SPutInItem(packet []byte) (*MsgC2SPutInItem, error) {
+	var msg MsgC2SPutInItem
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SPutOutItem struct {
+	MsgHead
+	Slot byte
+}
+
+func (msg *MsgC2SPutOutItem) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SPutOutItem) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SPutOutItem) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SPutOutItem(pcId uint32, slot byte) *MsgC2SPutOutItem {
+	msg := MsgC2SPutOutItem{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SPutOutItem,
+		},
+		Slot: slot,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SPutOutItem(packet []byte) (*MsgC2SPutOutItem, error) {
+	var msg MsgC2SPutOutItem
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SDecideDeal struct {
+	MsgHead
+	Woonz uint32
+}
+
+func (msg *MsgC2SDecideDeal) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SDecideDeal) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SDecideDeal) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SDecideDeal(pcId uint32, woonz uint32) *MsgC2SDecideDeal {
+	msg := MsgC2SDecideDeal{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SDecideDeal,
+		},
+		Woonz: woonz,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SDecideDeal(packet []byte) (*MsgC2SDecideDeal, error) {
+	var msg MsgC2SDecideDeal
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SConfirmDeal struct {
+	MsgHead
+	Confirm byte
+}
+
+func (msg *MsgC2SConfirmDeal) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SConfirmDeal) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SConfirmDeal) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SConfirmDeal(pcId uint32, confirm byte) *MsgC2SConfirmDeal {
+	msg := MsgC2SConfirmDeal{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SConfirmDeal,
+		},
+		Confirm: confirm,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SConfirmDeal(packet []byte) (*MsgC2SConfirmDeal, error) {
+	var msg MsgC2SConfirmDeal
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SAskOpenStorage struct {
+	MsgHead
+	NpcId uint32
+}
+
+func (msg *MsgC2SAskOpenStorage) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SAskOpenStorage) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SAskOpenStorage) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SAskOpenStorage(pcId uint32, npcId uint32) *MsgC2SAskOpenStorage {
+	msg := MsgC2SAskOpenStorage{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SAskOpenStorage,
+		},
+		NpcId: npcId,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SAskOpenStorage(packet []byte) (*MsgC2SAskOpenStorage, error) {
+	var msg MsgC2SAskOpenStorage
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SAskInven2Storage struct {
+	MsgHead
+	InventorySlot byte
+	StorageSlot   byte
+}
+
+func (msg *MsgC2SAskInven2Storage) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SAskInven2Storage) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SAskInven2Storage) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SAskInven2Storage(pcId uint32, inventorySlot byte, storageSlot byte) *MsgC2SAskInven2Storage {
+	msg := MsgC2SAskInven2Storage{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SAskInven2Storage,
+		},
+		InventorySlot: inventorySlot,
+		StorageSlot:   storageSlot,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SAskInven2Storage(packet []byte) (*MsgC2SAskInven2Storage, error) {
+	var msg MsgC2SAskInven2Storage
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SAskStorage2Inven struct {
+	MsgHead
+	StorageSlot   byte
+	InventorySlot byte
+}
+
+func (msg *MsgC2SAskStorage2Inven) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SAskStorage2Inven) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SAskStorage2Inven) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SAskStorage2Inven(pcId uint32, storageSlot byte, inventorySlot byte) *MsgC2SAskStorage2Inven {
+	msg := MsgC2SAskStorage2Inven{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SAskStorage2Inven,
+		},
+		StorageSlot:   storageSlot,
+		InventorySlot: inventorySlot,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SAskStorage2Inven(packet []byte) (*MsgC2SAskStorage2Inven, error) {
+	var msg MsgC2SAskStorage2Inven
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SAskMoveItemInStorage struct {
+	MsgHead
+	FromSlot byte
+	ToSlot   byte
+}
+
+func (msg *MsgC2SAskMoveItemInStorage) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SAskMoveItemInStorage) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SAskMoveItemInStorage) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SAskMoveItemInStorage(pcId uint32, fromSlot byte, toSlot byte) *MsgC2SAskMoveItemInStorage {
+	msg := MsgC2SAskMoveItemInStorage{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SAskMoveItemInStorage,
+		},
+		FromSlot: fromSlot,
+		ToSlot:   toSlot,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SAskMoveItemInStorage(packet []byte) (*MsgC2SAskMoveItemInStorage, error) {
+	var msg MsgC2SAskMoveItemInStorage
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SAskDepositeMoney struct {
+	MsgHead
+	Woonz uint32
+}
+
+func (msg *MsgC2SAskDepositeMoney) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SAskDepositeMoney) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SAskDepositeMoney) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SAskDepositeMoney(pcId uint32, woonz uint32) *MsgC2SAskDepositeMoney {
+	msg := MsgC2SAskDepositeMoney{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SAskDepositeMoney,
+		},
+		Woonz: woonz,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SAskDepositeMoney(packet []byte) (*MsgC2SAskDepositeMoney, error) {
+	var msg MsgC2SAskDepositeMoney
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SAskWithdrawMoney struct {
+	MsgHead
+	Woonz uint32
+}
+
+func (msg *MsgC2SAskWithdrawMoney) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SAskWithdrawMoney) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SAskWithdrawMoney) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SAskWithdrawMoney(pcId uint32, woonz uint32) *MsgC2SAskWithdrawMoney {
+	msg := MsgC2SAskWithdrawMoney{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SAskWithdrawMoney,
+		},
+		Woonz: woonz,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SAskWithdrawMoney(packet []byte) (*MsgC2SAskWithdrawMoney, error) {
+	var msg MsgC2SAskWithdrawMoney
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SAskCloseStorage struct {
+	MsgHead
+}
+
+func (msg *MsgC2SAskCloseStorage) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SAskCloseStorage) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SAskCloseStorage) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SAskCloseStorage(pcId uint32) *MsgC2SAskCloseStorage {
+	msg := MsgC2SAskCloseStorage{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SAskCloseStorage,
+		},
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SAskCloseStorage(packet []byte) (*MsgC2SAskCloseStorage, error) {
+	var msg MsgC2SAskCloseStorage
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SUsePotion struct {
+	MsgHead
+	Slot byte
+}
+
+func (msg *MsgC2SUsePotion) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SUsePotion) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SUsePotion) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SUsePotion(pcId uint32, slot byte) *MsgC2SUsePotion {
+	msg := MsgC2SUsePotion{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SUsePotion,
+		},
+		Slot: slot,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SUsePotion(packet []byte) (*MsgC2SUsePotion, error) {
+	var msg MsgC2SUsePotion
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SUsePotionEx struct {
+	MsgHead
+	ItemCode uint32
+}
+
+func (msg *MsgC2SUsePotionEx) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SUsePotionEx) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SUsePotionEx) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SUsePotionEx(pcId uint32, itemCode uint32) *MsgC2SUsePotionEx {
+	msg := MsgC2SUsePotionEx{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SUsePotionEx,
+		},
+		ItemCode: itemCode,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SUsePotionEx(packet []byte) (*MsgC2SUsePotionEx, error) {
+	var msg MsgC2SUsePotionEx
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SUseItem struct {
+	MsgHead
+	Slot byte
+}
+
+func (msg *MsgC2SUseItem) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SUseItem) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SUseItem) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SUseItem(pcId uint32, slot byte) *MsgC2SUseItem {
+	msg := MsgC2SUseItem{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SUseItem,
+		},
+		Slot: slot,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SUseItem(packet []byte) (*MsgC2SUseItem, error) {
+	var msg MsgC2SUseItem
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SLearnSkill struct {
+	MsgHead
+	Slot byte
+}
+
+func (msg *MsgC2SLearnSkill) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SLearnSkill) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SLearnSkill) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SLearnSkill(pcId uint32, slot byte) *MsgC2SLearnSkill {
+	msg := MsgC2SLearnSkill{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SLearnSkill,
+		},
+		Slot: slot,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SLearnSkill(packet []byte) (*MsgC2SLearnSkill, error) {
+	var msg MsgC2SLearnSkill
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SAskSkill struct {
+	MsgHead
+	SkillId  byte
+	TargetId uint32
+}
+
+func (msg *MsgC2SAskSkill) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SAskSkill) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SAskSkill) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SAskSkill(pcId uint32, skillId byte, targetId uint32) *MsgC2SAskSkill {
+	msg := MsgC2SAskSkill{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SAskSkill,
+		},
+		SkillId:  skillId,
+		TargetId: targetId,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SAskSkill(packet []byte) (*MsgC2SAskSkill, error) {
+	var msg MsgC2SAskSkill
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SSkillSlotInfo struct {
+	MsgHead
+	SkillSlots [0x14]byte
+}
+
+func (msg *MsgC2SSkillSlotInfo) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SSkillSlotInfo) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SSkillSlotInfo) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SSkillSlotInfo(pcId uint32, skillSlots [0x14]byte) *MsgC2SSkillSlotInfo {
+	msg := MsgC2SSkillSlotInfo{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SSkillSlotInfo,
+		},
+		SkillSlots: skillSlots,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SSkillSlotInfo(packet []byte) (*MsgC2SSkillSlotInfo, error) {
+	var msg MsgC2SSkillSlotInfo
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SLearnPskill struct {
+	MsgHead
+	SkillId byte
+}
+
+func (msg *MsgC2SLearnPskill) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SLearnPskill) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SLearnPskill) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SLearnPskill(pcId uint32, skillId byte) *MsgC2SLearnPskill {
+	msg := MsgC2SLearnPskill{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SLearnPskill,
+		},
+		SkillId: skillId,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SLearnPskill(packet []byte) (*MsgC2SLearnPskill, error) {
+	var msg MsgC2SLearnPskill
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SForgetAllPskill struct {
+	MsgHead
+}
+
+func (msg *MsgC2SForgetAllPskill) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SForgetAllPskill) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SForgetAllPskill) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SForgetAllPskill(pcId uint32) *MsgC2SForgetAllPskill {
+	msg := MsgC2SForgetAllPskill{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SForgetAllPskill,
+		},
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SForgetAllPskill(packet []byte) (*MsgC2SForgetAllPskill, error) {
+	var msg MsgC2SForgetAllPskill
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SAllotPoint struct {
+	MsgHead
+	Stat   byte
+	Points uint16
+}
+
+func (msg *MsgC2SAllotPoint) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SAllotPoint) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SAllotPoint) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SAllotPoint(pcId uint32, stat byte, points uint16) *MsgC2SAllotPoint {
+	msg := MsgC2SAllotPoint{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SAllotPoint,
+		},
+		Stat:   stat,
+		Points: points,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SAllotPoint(packet []byte) (*MsgC2SAllotPoint, error) {
+	var msg MsgC2SAllotPoint
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SRetrievePoint struct {
+	MsgHead
+	Stat   byte
+	Points uint16
+}
+
+func (msg *MsgC2SRetrievePoint) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SRetrievePoint) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SRetrievePoint) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SRetrievePoint(pcId uint32, stat byte, points uint16) *MsgC2SRetrievePoint {
+	msg := MsgC2SRetrievePoint{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SRetrievePoint,
+		},
+		Stat:   stat,
+		Points: points,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SRetrievePoint(packet []byte) (*MsgC2SRetrievePoint, error) {
+	var msg MsgC2SRetrievePoint
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SHsAllotPoint struct {
+	MsgHead
+	HsId   uint32
+	Stat   byte
+	Points uint16
+}
+
+func (msg *MsgC2SHsAllotPoint) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SHsAllotPoint) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SHsAllotPoint) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SHsAllotPoint(pcId uint32, hsId uint32, stat byte, points uint16) *MsgC2SHsAllotPoint {
+	msg := MsgC2SHsAllotPoint{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SHsAllotPoint,
+		},
+		HsId:   hsId,
+		Stat:   stat,
+		Points: points,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SHsAllotPoint(packet []byte) (*MsgC2SHsAllotPoint, error) {
+	var msg MsgC2SHsAllotPoint
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SHsRetrievePoint struct {
+	MsgHead
+	HsId   uint32
+	Stat   byte
+	Points uint16
+}
+
+func (msg *MsgC2SHsRetrievePoint) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SHsRetrievePoint) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SHsRetrievePoint) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SHsRetrievePoint(pcId uint32, hsId uint32, stat byte, points uint16) *MsgC2SHsRetrievePoint {
+	msg := MsgC2SHsRetrievePoint{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SHsRetrievePoint,
+		},
+		HsId:   hsId,
+		Stat:   stat,
+		Points: points,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SHsRetrievePoint(packet []byte) (*MsgC2SHsRetrievePoint, error) {
+	var msg MsgC2SHsRetrievePoint
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SAskParty struct {
+	MsgHead
+	TargetId uint32
+}
+
+func (msg *MsgC2SAskParty) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SAskParty) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SAskParty) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SAskParty(pcId uint32, targetId uint32) *MsgC2SAskParty {
+	msg := MsgC2SAskParty{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SAskParty,
+		},
+		TargetId: targetId,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SAskParty(packet []byte) (*MsgC2SAskParty, error) {
+	var msg MsgC2SAskParty
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SAnsParty struct {
+	MsgHead
+	RequesterId uint32
+	Accept      byte
+}
+
+func (msg *MsgC2SAnsParty) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SAnsParty) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SAnsParty) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SAnsParty(pcId uint32, requesterId uint32, accept byte) *MsgC2SAnsParty {
+	msg := MsgC2SAnsParty{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SAnsParty,
+		},
+		RequesterId: requesterId,
+		Accept:      accept,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SAnsParty(packet []byte) (*MsgC2SAnsParty, error) {
+	var msg MsgC2SAnsParty
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SOutParty struct {
+	MsgHead
+	TargetId uint32
+}
+
+func (msg *MsgC2SOutParty) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SOutParty) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SOutParty) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SOutParty(pcId uint32, targetId uint32) *MsgC2SOutParty {
+	msg := MsgC2SOutParty{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SOutParty,
+		},
+		TargetId: targetId,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SOutParty(packet []byte) (*MsgC2SOutParty, error) {
+	var msg MsgC2SOutParty
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SClan struct {
+	MsgHead
+	Command  byte
+	TargetId uint32
+	Rank     byte
+	ClanName [0x15]byte
+}
+
+func (msg *MsgC2SClan) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SClan) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SClan) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SClan(pcId uint32, command byte, targetId uint32, rank byte, clanName string) *MsgC2SClan {
+	msg := MsgC2SClan{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SClan,
+		},
+		Command:  command,
+		TargetId: targetId,
+		Rank:     rank,
+	}
+
+	copy(msg.ClanName[:], utils.MakeFixedLengthStringBytes(clanName, 0x15))
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SClan(packet []byte) (*MsgC2SClan, error) {
+	var msg MsgC2SClan
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SJoinClan struct {
+	MsgHead
+	TargetId uint32
+}
+
+func (msg *MsgC2SJoinClan) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SJoinClan) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SJoinClan) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SJoinClan(pcId uint32, targetId uint32) *MsgC2SJoinClan {
+	msg := MsgC2SJoinClan{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SJoinClan,
+		},
+		TargetId: targetId,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SJoinClan(packet []byte) (*MsgC2SJoinClan, error) {
+	var msg MsgC2SJoinClan
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SAnsClan struct {
+	MsgHead
+	RequesterId uint32
+	Accept      byte
+}
+
+func (msg *MsgC2SAnsClan) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SAnsClan) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SAnsClan) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SAnsClan(pcId uint32, requesterId uint32, accept byte) *MsgC2SAnsClan {
+	msg := MsgC2SAnsClan{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SAnsClan,
+		},
+		RequesterId: requesterId,
+		Accept:      accept,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SAnsClan(packet []byte) (*MsgC2SAnsClan, error) {
+	var msg MsgC2SAnsClan
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SBoltClan struct {
+	MsgHead
+	TargetId uint32
+}
+
+func (msg *MsgC2SBoltClan) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SBoltClan) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SBoltClan) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SBoltClan(pcId uint32, targetId uint32) *MsgC2SBoltClan {
+	msg := MsgC2SBoltClan{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SBoltClan,
+		},
+		TargetId: targetId,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SBoltClan(packet []byte) (*MsgC2SBoltClan, error) {
+	var msg MsgC2SBoltClan
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SReqClanInfo struct {
+	MsgHead
+}
+
+func (msg *MsgC2SReqClanInfo) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SReqClanInfo) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SReqClanInfo) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SReqClanInfo(pcId uint32) *MsgC2SReqClanInfo {
+	msg := MsgC2SReqClanInfo{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SReqClanInfo,
+		},
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SReqClanInfo(packet []byte) (*MsgC2SReqClanInfo, error) {
+	var msg MsgC2SReqClanInfo
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2ZRegisterMark struct {
+	MsgHead
+	MarkSize uint16
+	Mark     [0x400]byte
+}
+
+func (msg *MsgC2ZRegisterMark) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2ZRegisterMark) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2ZRegisterMark) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2ZRegisterMark(pcId uint32, markSize uint16, mark [0x400]byte) *MsgC2ZRegisterMark {
+	msg := MsgC2ZRegisterMark{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2ZRegisterMark,
+		},
+		MarkSize: markSize,
+		Mark:     mark,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+// ReadMsgC2ZRegisterMark reads a clan mark upload. The client only sends as
+// much of the mark as it uses, so a short packet is read as if the rest of
+// the mark were zero, while a packet longer than the message is rejected.
+func ReadMsgC2ZRegisterMark(packet []byte) (*MsgC2ZRegisterMark, error) {
+	var msg MsgC2ZRegisterMark
+	if len(packet) > int(msg.GetSize()) {
+		return nil, errors.New("clan mark packet is too large")
+	}
+
+	padded := make([]byte, msg.GetSize())
+	copy(padded, packet)
+	if err := binary.Read(bytes.NewReader(padded), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	if int(msg.MarkSize) > len(msg.Mark) || int(msg.MarkSize) > len(packet)-int(msg.GetSize())+len(msg.Mark) {
+		return nil, errors.New("clan mark size does not match the packet")
+	}
+
+	return &msg, nil
+}
+
+type MsgC2STransferMark struct {
+	MsgHead
+	ClanId      uint32
+	MarkVersion uint32
+}
+
+func (msg *MsgC2STransferMark) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2STransferMark) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2STransferMark) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2STransferMark(pcId uint32, clanId uint32, markVersion uint32) *MsgC2STransferMark {
+	msg := MsgC2STransferMark{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2STransferMark,
+		},
+		ClanId:      clanId,
+		MarkVersion: markVersion,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2STransferMark(packet []byte) (*MsgC2STransferMark, error) {
+	var msg MsgC2STransferMark
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgC2SAskMark struct {
+	MsgHead
+	ClanId uint32
+}
+
+func (msg *MsgC2SAskMark) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgC2SAskMark) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgC2SAskMark) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgC2SAskMark(pcId uint32, clanId uint32) *MsgC2SAskMark {
+	msg := MsgC2SAskMark{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.C2SAskMark,
+		},
+		ClanId: clanId,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgC2SAskMark(packet []byte) (*MsgC2SAskMark, error) {
+	var msg MsgC2SAskMark
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
@@ -128,3 +128,399 @@ func ReadMsgM2SWorldLogin(packet []byte) (*MsgM2SWorldLogin, error) {
 
 	return &msg, nil
 }
+
+type MsgM2SZoneChange struct {
+	MsgHeadMs
+	MapId        uint16
+	ZoneServerId byte
+	Result       byte
+}
+
+func (msg *MsgM2SZoneChange) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgM2SZoneChange) SetSize() {
+	msg.Size = uint16(msg.GetSize())
+}
+
+func (msg *MsgM2SZoneChange) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgM2SZoneChange(pcId uint32, mapId uint16, zoneServerId byte, result byte, gateServerId byte) *MsgM2SZoneChange {
+	msg := MsgM2SZoneChange{
+		MsgHeadMs:    MsgHeadMs{Protocol: protocol.M2SZoneChange, GateServerId: gateServerId, PcId: pcId},
+		MapId:        mapId,
+		ZoneServerId: zoneServerId,
+		Result:       result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgM2SZoneChange(packet []byte) (*MsgM2SZoneChange, error) {
+	var msg MsgM2SZoneChange
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgM2SWhisper struct {
+	MsgHeadMs
+	SenderName [0x15]byte
+	Message    [0x64]byte
+}
+
+func (msg *MsgM2SWhisper) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgM2SWhisper) SetSize() {
+	msg.Size = uint16(msg.GetSize())
+}
+
+func (msg *MsgM2SWhisper) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgM2SWhisper(pcId uint32, senderName string, message string, gateServerId byte) *MsgM2SWhisper {
+	msg := MsgM2SWhisper{
+		MsgHeadMs: MsgHeadMs{Protocol: protocol.M2SWhisper, GateServerId: gateServerId, PcId: pcId},
+	}
+
+	copy(msg.SenderName[:], utils.MakeFixedLengthStringBytes(senderName, 0x15))
+	copy(msg.Message[:], utils.MakeFixedLengthStringBytes(message, 0x64))
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgM2SWhisper(packet []byte) (*MsgM2SWhisper, error) {
+	var msg MsgM2SWhisper
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgM2SAskParty struct {
+	MsgHeadMs
+	RequesterId   uint32
+	RequesterName [0x15]byte
+}
+
+func (msg *MsgM2SAskParty) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgM2SAskParty) SetSize() {
+	msg.Size = uint16(msg.GetSize())
+}
+
+func (msg *MsgM2SAskParty) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgM2SAskParty(pcId uint32, requesterId uint32, requesterName string, gateServerId byte) *MsgM2SAskParty {
+	msg := MsgM2SAskParty{
+		MsgHeadMs:   MsgHeadMs{Protocol: protocol.M2SAskParty, GateServerId: gateServerId, PcId: pcId},
+		RequesterId: requesterId,
+	}
+
+	copy(msg.RequesterName[:], utils.MakeFixedLengthStringBytes(requesterName, 0x15))
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgM2SAskParty(packet []byte) (*MsgM2SAskParty, error) {
+	var msg MsgM2SAskParty
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgM2SAnsParty struct {
+	MsgHeadMs
+	PartnerId uint32
+	Result    byte
+}
+
+func (msg *MsgM2SAnsParty) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgM2SAnsParty) SetSize() {
+	msg.Size = uint16(msg.GetSize())
+}
+
+func (msg *MsgM2SAnsParty) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgM2SAnsParty(pcId uint32, partnerId uint32, result byte, gateServerId byte) *MsgM2SAnsParty {
+	msg := MsgM2SAnsParty{
+		MsgHeadMs: MsgHeadMs{Protocol: protocol.M2SAnsParty, GateServerId: gateServerId, PcId: pcId},
+		PartnerId: partnerId,
+		Result:    result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgM2SAnsParty(packet []byte) (*MsgM2SAnsParty, error) {
+	var msg MsgM2SAnsParty
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgM2SPartyInfo struct {
+	MsgHeadMs
+	LeaderId    uint32
+	MemberCount byte
+	Members     [0x08]PartyMember
+}
+
+func (msg *MsgM2SPartyInfo) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgM2SPartyInfo) SetSize() {
+	msg.Size = uint16(msg.GetSize())
+}
+
+func (msg *MsgM2SPartyInfo) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgM2SPartyInfo(pcId uint32, leaderId uint32, gateServerId byte) *MsgM2SPartyInfo {
+	msg := MsgM2SPartyInfo{
+		MsgHeadMs: MsgHeadMs{Protocol: protocol.M2SPartyInfo, GateServerId: gateServerId, PcId: pcId},
+		LeaderId:  leaderId,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgM2SPartyInfo(packet []byte) (*MsgM2SPartyInfo, error) {
+	var msg MsgM2SPartyInfo
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgM2SPartyMemberUpdate struct {
+	MsgHeadMs
+	Member PartyMember
+}
+
+func (msg *MsgM2SPartyMemberUpdate) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgM2SPartyMemberUpdate) SetSize() {
+	msg.Size = uint16(msg.GetSize())
+}
+
+func (msg *MsgM2SPartyMemberUpdate) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgM2SPartyMemberUpdate(pcId uint32, member PartyMember, gateServerId byte) *MsgM2SPartyMemberUpdate {
+	msg := MsgM2SPartyMemberUpdate{
+		MsgHeadMs: MsgHeadMs{Protocol: protocol.M2SPartyMemberUpdate, GateServerId: gateServerId, PcId: pcId},
+		Member:    member,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgM2SPartyMemberUpdate(packet []byte) (*MsgM2SPartyMemberUpdate, error) {
+	var msg MsgM2SPartyMemberUpdate
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgM2SJoinClan struct {
+	MsgHeadMs
+	RequesterId          uint32
+	ClanId               uint32
+	ClanName             [0x15]byte
+	RequesterCharacterId uint32
+}
+
+func (msg *MsgM2SJoinClan) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgM2SJoinClan) SetSize() {
+	msg.Size = uint16(msg.GetSize())
+}
+
+func (msg *MsgM2SJoinClan) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgM2SJoinClan(pcId uint32, requesterId uint32, clanId uint32, clanName string, requesterCharacterId uint32, gateServerId byte) *MsgM2SJoinClan {
+	msg := MsgM2SJoinClan{
+		MsgHeadMs:            MsgHeadMs{Protocol: protocol.M2SJoinClan, GateServerId: gateServerId, PcId: pcId},
+		RequesterId:          requesterId,
+		ClanId:               clanId,
+		RequesterCharacterId: requesterCharacterId,
+	}
+
+	copy(msg.ClanName[:], utils.MakeFixedLengthStringBytes(clanName, 0x15))
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgM2SJoinClan(packet []byte) (*MsgM2SJoinClan, error) {
+	var msg MsgM2SJoinClan
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgM2SAnsClan struct {
+	MsgHeadMs
+	PartnerId uint32
+	Result    byte
+}
+
+func (msg *MsgM2SAnsClan) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgM2SAnsClan) SetSize() {
+	msg.Size = uint16(msg.GetSize())
+}
+
+func (msg *MsgM2SAnsClan) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgM2SAnsClan(pcId uint32, partnerId uint32, result byte, gateServerId byte) *MsgM2SAnsClan {
+	msg := MsgM2SAnsClan{
+		MsgHeadMs: MsgHeadMs{Protocol: protocol.M2SAnsClan, GateServerId: gateServerId, PcId: pcId},
+		PartnerId: partnerId,
+		Result:    result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgM2SAnsClan(packet []byte) (*MsgM2SAnsClan, error) {
+	var msg MsgM2SAnsClan
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgM2SClanUpdate struct {
+	MsgHeadMs
+}
+
+func (msg *MsgM2SClanUpdate) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgM2SClanUpdate) SetSize() {
+	msg.Size = uint16(msg.GetSize())
+}
+
+func (msg *MsgM2SClanUpdate) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgM2SClanUpdate(pcId uint32, gateServerId byte) *MsgM2SClanUpdate {
+	msg := MsgM2SClanUpdate{
+		MsgHeadMs: MsgHeadMs{Protocol: protocol.M2SClanUpdate, GateServerId: gateServerId, PcId: pcId},
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgM2SClanUpdate(packet []byte) (*MsgM2SClanUpdate, error) {
+	var msg MsgM2SClanUpdate
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgM2SNationChat struct {
+	MsgHeadMs
+	Nation     byte
+	SenderName [0x15]byte
+	Message    [0x64]byte
+}
+
+func (msg *MsgM2SNationChat) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgM2SNationChat) SetSize() {
+	msg.Size = uint16(msg.GetSize())
+}
+
+func (msg *MsgM2SNationChat) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgM2SNationChat(pcId uint32, nation byte, senderName string, message string, gateServerId byte) *MsgM2SNationChat {
+	msg := MsgM2SNationChat{
+		MsgHeadMs: MsgHeadMs{Protocol: protocol.M2SNationChat, GateServerId: gateServerId, PcId: pcId},
+		Nation:    nation,
+	}
+
+	copy(msg.SenderName[:], utils.MakeFixedLengthStringBytes(senderName, 0x15))
+	copy(msg.Message[:], utils.MakeFixedLengthStringBytes(message, 0x64))
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgM2SNationChat(packet []byte) (*MsgM2SNationChat, error) {
+	var msg MsgM2SNationChat
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
@@ -48,47 +48,77 @@ const C2SAskNpcFavor uint16 = 0x1308
 const C2SNpcFavorUp uint16 = 0x1309
 
 const C2SAskAttack uint16 = 0x1400
+const S2CAttack uint16 = 0x1400
+const S2CDie uint16 = 0x1401
 const C2SLearnSkill uint16 = 0x1451
+const S2CLearnSkill uint16 = 0x1451
 const C2SAskSkill uint16 = 0x1453
+const S2CSkill uint16 = 0x1453
 const C2SSkillSlotInfo uint16 = 0x1461
 const S2CSkillSlotInfo uint16 = 0x1461
 const C2SAnsRecall uint16 = 0x1462
 
+const S2CExp uint16 = 0x1600
+const S2CLevelUp uint16 = 0x1601
+const S2CPcStats uint16 = 0x1602
 const C2SAllotPoint uint16 = 0x1602
 const C2SAskHeal uint16 = 0x1606
 const C2SRetrievePoint uint16 = 0x1609
+const S2CRetrievePoint uint16 = 0x1609
 const C2SRestoreExp uint16 = 0x160C
 const S2CUnknown37Protocol uint16 = 0x1610
 const C2SLearnPskill uint16 = 0x1611
+const S2CLearnPskill uint16 = 0x1611
 const C2SForgetAllPskill uint16 = 0x1613
+const S2CForgetAllPskill uint16 = 0x1613
 const C2SAskOpenStorage uint16 = 0x1651
+const S2COpenStorage uint16 = 0x1651
 const C2SAskInven2Storage uint16 = 0x1652
+const S2CInven2Storage uint16 = 0x1652
 const C2SAskStorage2Inven uint16 = 0x1653
+const S2CStorage2Inven uint16 = 0x1653
 const C2SAskDepositeMoney uint16 = 0x1654
+const S2CDepositeMoney uint16 = 0x1654
 const C2SAskWithdrawMoney uint16 = 0x1655
+const S2CWithdrawMoney uint16 = 0x1655
 const C2SAskCloseStorage uint16 = 0x1656
 const C2SAskMoveItemInStorage uint16 = 0x1657
+const S2CMoveItemInStorage uint16 = 0x1657
 
+const S2CItemAppear uint16 = 0x1700
+const S2CItemDisappear uint16 = 0x1701
 const C2SPickupItem uint16 = 0x1702
+const S2CPickupItem uint16 = 0x1702
 const C2SDropItem uint16 = 0x1704
+const S2CDropItem uint16 = 0x1704
 const C2SMoveItem uint16 = 0x1706
+const S2CMoveItem uint16 = 0x1706
 const C2SWearItem uint16 = 0x1708
+const S2CWearItem uint16 = 0x1708
 const C2SStripItem uint16 = 0x1711
+const S2CStripItem uint16 = 0x1711
 const C2SBuyItem uint16 = 0x1714
+const S2CBuyItem uint16 = 0x1714
 const C2SSellItem uint16 = 0x1716
+const S2CSellItem uint16 = 0x1716
 const C2SGiveItem uint16 = 0x1718
 const S2CGiveItem uint16 = 0x1720
 const C2SUsePotion uint16 = 0x1721
+const S2CUsePotion uint16 = 0x1721
 const C2SAskDeal uint16 = 0x1723
 const S2CAskDeal uint16 = 0x1724
 const C2SAnsDeal uint16 = 0x1725
+const S2CAnsDeal uint16 = 0x1726
 const C2SPutInItem uint16 = 0x1727
 const S2CPutInItem uint16 = 0x1728
 const C2SPutOutItem uint16 = 0x1729
 const S2CPutOutItem uint16 = 0x1730
 const C2SDecideDeal uint16 = 0x1731
+const S2CDecideDeal uint16 = 0x1732
 const C2SConfirmDeal uint16 = 0x1733
+const S2CConfirmDeal uint16 = 0x1734
 const C2SUseItem uint16 = 0x1736
+const S2CUseItem uint16 = 0x1736
 const C2SConfirmItem uint16 = 0x1742
 const C2SRemodelItem uint16 = 0x1744
 const C2SUseScroll uint16 = 0x1748
@@ -122,6 +152,7 @@ const C2SDerbyHistoryQuery uint16 = 0x17AF
 const C2SDerbyExchange uint16 = 0x17B0
 
 const C2SSay uint16 = 0x1800
+const S2CSay uint16 = 0x1800
 const C2SGesture uint16 = 0x1801
 const C2SChatWindowOpt uint16 = 0x1803
 const S2CChatWindowOpt uint16 = 0x1803
@@ -150,20 +181,32 @@ const C2SSquestHboyHanoi uint16 = 0x2159
 const C2SSquest346ItemCombi uint16 = 0x215E
 
 const C2SAskParty uint16 = 0x2200
+const S2CAskParty uint16 = 0x2200
 const C2SAnsParty uint16 = 0x2202
+const S2CAnsParty uint16 = 0x2202
+const S2CPartyInfo uint16 = 0x2203
+const S2CPartyMemberUpdate uint16 = 0x2204
 const C2SOutParty uint16 = 0x2205
 const C2SAskApprenticeIn uint16 = 0x22A0
 const C2SAnsApprenticeIn uint16 = 0x22A1
 const C2SAskApprenticeOut uint16 = 0x22A4
 
 const C2SClan uint16 = 0x2300
+const S2CClan uint16 = 0x2300
 const C2SJoinClan uint16 = 0x2301
+const S2CJoinClan uint16 = 0x2301
 const C2SAnsClan uint16 = 0x2302
+const S2CAnsClan uint16 = 0x2302
 const C2SBoltClan uint16 = 0x2303
+const S2CBoltClan uint16 = 0x2303
 const C2SReqClanInfo uint16 = 0x2304
+const S2CClanInfo uint16 = 0x2304
 const C2ZRegisterMark uint16 = 0x2320
+const S2CRegisterMark uint16 = 0x2320
 const C2STransferMark uint16 = 0x2322
+const S2CTransferMark uint16 = 0x2322
 const C2SAskMark uint16 = 0x2323
+const S2CAskMark uint16 = 0x2323
 const C2SFriendInfo uint16 = 0x2331
 const C2SFriendState uint16 = 0x2332
 const S2CFriendState uint16 = 0x2332
@@ -228,9 +271,13 @@ const C2SHsStoneBuy uint16 = 0x5008
 const C2SHsStoneSell uint16 = 0x5009
 const C2SHsLearnSkill uint16 = 0x500A
 const C2SHsAllotPoint uint16 = 0x500B
+const S2CHsAllotPoint uint16 = 0x500B
 const C2SHsRetrievePoint uint16 = 0x500C
+const S2CHsRetrievePoint uint16 = 0x500C
 const C2SHsWearItem uint16 = 0x500D
+const S2CHsWearItem uint16 = 0x500D
 const C2SHsStripItem uint16 = 0x5010
+const S2CHsStripItem uint16 = 0x5010
 const C2SHsOption uint16 = 0x501B
 const C2SHsHeal uint16 = 0x501C
 const C2SHsSkillReset uint16 = 0x501E
@@ -246,6 +293,26 @@ const S2MCharacterLogin uint16 = 0xA010
 const S2MWorldLogin uint16 = 0xA011
 const M2SWorldLogin uint16 = 0xA011
 const S2MCharacterLogout uint16 = 0xA012
+const S2MZoneChange uint16 = 0xA013
+const M2SZoneChange uint16 = 0xA013
+const S2MWhisper uint16 = 0xA014
+const M2SWhisper uint16 = 0xA014
+const S2MAskParty uint16 = 0xA015
+const M2SAskParty uint16 = 0xA015
+const S2MAnsParty uint16 = 0xA016
+const M2SAnsParty uint16 = 0xA016
+const S2MOutParty uint16 = 0xA017
+const M2SPartyInfo uint16 = 0xA018
+const S2MPartyMemberUpdate uint16 = 0xA019
+const M2SPartyMemberUpdate uint16 = 0xA019
+const S2MJoinClan uint16 = 0xA01A
+const M2SJoinClan uint16 = 0xA01A
+const S2MAnsClan uint16 = 0xA01B
+const M2SAnsClan uint16 = 0xA01B
+const S2MClanUpdate uint16 = 0xA01C
+const M2SClanUpdate uint16 = 0xA01C
+const S2MNationChat uint16 = 0xA01D
+const M2SNationChat uint16 = 0xA01D
 
 const C2SLeague uint16 = 0xA340
 const C2SReqLeagueClanInfo uint16 = 0xA345
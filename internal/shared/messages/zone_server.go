@@ -133,3 +133,3124 @@ func ReadMsgS2CWorldLogin(packet []byte) (*MsgS2CWorldLogin, error) {
 
 	return &msg, nil
 }
+
+type MsgS2CPcAppear struct {
+	MsgHead
+	AppearPcId    uint32
+	CharacterName [0x15]byte
+	Class         byte
+	Level         uint16
+	MapCell       uint32
+	SocialInfo    SocialInfo
+	WearList      [0xA]CharacterWear
+	ActivePet     Pet
+}
+
+func (msg *MsgS2CPcAppear) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CPcAppear) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CPcAppear) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CPcAppear(pcId uint32, appearPcId uint32, characterName string) *MsgS2CPcAppear {
+	msg := MsgS2CPcAppear{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CPcAppear,
+		},
+		AppearPcId: appearPcId,
+	}
+
+	copy(msg.CharacterName[:], utils.MakeFixedLengthStringBytes(characterName, 0x15))
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CPcAppear(packet []byte) (*MsgS2CPcAppear, error) {
+	var msg MsgS2CPcAppear
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CPcDisappear struct {
+	MsgHead
+	DisappearPcId uint32
+}
+
+func (msg *MsgS2CPcDisappear) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CPcDisappear) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CPcDisappear) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CPcDisappear(pcId uint32, disappearPcId uint32) *MsgS2CPcDisappear {
+	msg := MsgS2CPcDisappear{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CPcDisappear,
+		},
+		DisappearPcId: disappearPcId,
+	}
+
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CPcDisappear(packet []byte) (*MsgS2CPcDisappear, error) {
+	var msg MsgS2CPcDisappear
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CAnsMove struct {
+	MsgHead
+	ObjectId   uint32
+	StartCell  uint32
+	TargetCell uint32
+}
+
+func (msg *MsgS2CAnsMove) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CAnsMove) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CAnsMove) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CAnsMove(pcId uint32, objectId uint32, startCell uint32, targetCell uint32) *MsgS2CAnsMove {
+	msg := MsgS2CAnsMove{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CAnsMove,
+		},
+		ObjectId:   objectId,
+		StartCell:  startCell,
+		TargetCell: targetCell,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CAnsMove(packet []byte) (*MsgS2CAnsMove, error) {
+	var msg MsgS2CAnsMove
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CSeeMove struct {
+	MsgHead
+	ObjectId   uint32
+	StartCell  uint32
+	TargetCell uint32
+}
+
+func (msg *MsgS2CSeeMove) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CSeeMove) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CSeeMove) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CSeeMove(pcId uint32, objectId uint32, startCell uint32, targetCell uint32) *MsgS2CSeeMove {
+	msg := MsgS2CSeeMove{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CSeeMove,
+		},
+		ObjectId:   objectId,
+		StartCell:  startCell,
+		TargetCell: targetCell,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CSeeMove(packet []byte) (*MsgS2CSeeMove, error) {
+	var msg MsgS2CSeeMove
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CSeeStop struct {
+	MsgHead
+	ObjectId uint32
+	Cell     uint32
+}
+
+func (msg *MsgS2CSeeStop) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CSeeStop) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CSeeStop) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CSeeStop(pcId uint32, objectId uint32, cell uint32) *MsgS2CSeeStop {
+	msg := MsgS2CSeeStop{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CSeeStop,
+		},
+		ObjectId: objectId,
+		Cell:     cell,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CSeeStop(packet []byte) (*MsgS2CSeeStop, error) {
+	var msg MsgS2CSeeStop
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CFixMove struct {
+	MsgHead
+	ObjectId uint32
+	Cell     uint32
+}
+
+func (msg *MsgS2CFixMove) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CFixMove) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CFixMove) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CFixMove(pcId uint32, objectId uint32, cell uint32) *MsgS2CFixMove {
+	msg := MsgS2CFixMove{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CFixMove,
+		},
+		ObjectId: objectId,
+		Cell:     cell,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CFixMove(packet []byte) (*MsgS2CFixMove, error) {
+	var msg MsgS2CFixMove
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CNpcInitialize struct {
+	MsgHead
+	NpcId       uint32
+	NpcCode     uint16
+	MapCell     uint32
+	Orientation byte
+	HP          uint32
+	MaxHP       uint32
+}
+
+func (msg *MsgS2CNpcInitialize) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CNpcInitialize) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CNpcInitialize) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CNpcInitialize(pcId uint32, npcId uint32, npcCode uint16, mapCell uint32, orientation byte, hp uint32, maxHP uint32) *MsgS2CNpcInitialize {
+	msg := MsgS2CNpcInitialize{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CNpcInitializeProtocol,
+		},
+		NpcId:       npcId,
+		NpcCode:     npcCode,
+		MapCell:     mapCell,
+		Orientation: orientation,
+		HP:          hp,
+		MaxHP:       maxHP,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CNpcInitialize(packet []byte) (*MsgS2CNpcInitialize, error) {
+	var msg MsgS2CNpcInitialize
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CAttack struct {
+	MsgHead
+	AttackerId uint32
+	TargetId   uint32
+	Damage     uint32
+	TargetHP   uint32
+}
+
+func (msg *MsgS2CAttack) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CAttack) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CAttack) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CAttack(pcId uint32, attackerId uint32, targetId uint32, damage uint32, targetHP uint32) *MsgS2CAttack {
+	msg := MsgS2CAttack{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CAttack,
+		},
+		AttackerId: attackerId,
+		TargetId:   targetId,
+		Damage:     damage,
+		TargetHP:   targetHP,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CAttack(packet []byte) (*MsgS2CAttack, error) {
+	var msg MsgS2CAttack
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CDie struct {
+	MsgHead
+	ObjectId uint32
+	KillerId uint32
+}
+
+func (msg *MsgS2CDie) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CDie) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CDie) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CDie(pcId uint32, objectId uint32, killerId uint32) *MsgS2CDie {
+	msg := MsgS2CDie{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CDie,
+		},
+		ObjectId: objectId,
+		KillerId: killerId,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CDie(packet []byte) (*MsgS2CDie, error) {
+	var msg MsgS2CDie
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CExp struct {
+	MsgHead
+	Exp uint32
+}
+
+func (msg *MsgS2CExp) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CExp) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CExp) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CExp(pcId uint32, exp uint32) *MsgS2CExp {
+	msg := MsgS2CExp{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CExp,
+		},
+		Exp: exp,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CExp(packet []byte) (*MsgS2CExp, error) {
+	var msg MsgS2CExp
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CLevelUp struct {
+	MsgHead
+	LevelUpPcId     uint32
+	Level           uint16
+	RemainingPoints uint16
+	HPCapacity      uint16
+	MPCapacity      uint16
+}
+
+func (msg *MsgS2CLevelUp) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CLevelUp) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CLevelUp) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CLevelUp(pcId uint32, levelUpPcId uint32, level uint16, remainingPoints uint16, hpCapacity uint16, mpCapacity uint16) *MsgS2CLevelUp {
+	msg := MsgS2CLevelUp{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CLevelUp,
+		},
+		LevelUpPcId:     levelUpPcId,
+		Level:           level,
+		RemainingPoints: remainingPoints,
+		HPCapacity:      hpCapacity,
+		MPCapacity:      mpCapacity,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CLevelUp(packet []byte) (*MsgS2CLevelUp, error) {
+	var msg MsgS2CLevelUp
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2MZoneChange struct {
+	MsgHeadMs
+	MapId uint16
+}
+
+func (msg *MsgS2MZoneChange) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2MZoneChange) SetSize() {
+	msg.Size = uint16(msg.GetSize())
+}
+
+func (msg *MsgS2MZoneChange) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2MZoneChange(pcId uint32, mapId uint16, gateServerId byte) *MsgS2MZoneChange {
+	msg := MsgS2MZoneChange{
+		MsgHeadMs: MsgHeadMs{Protocol: protocol.S2MZoneChange, GateServerId: gateServerId, PcId: pcId},
+		MapId:     mapId,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2MZoneChange(packet []byte) (*MsgS2MZoneChange, error) {
+	var msg MsgS2MZoneChange
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CSay struct {
+	MsgHead
+	ChatType   byte
+	SenderId   uint32
+	SenderName [0x15]byte
+	Message    [0x64]byte
+}
+
+func (msg *MsgS2CSay) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CSay) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CSay) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CSay(pcId uint32, chatType byte, senderId uint32, senderName string, message string) *MsgS2CSay {
+	msg := MsgS2CSay{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CSay,
+		},
+		ChatType: chatType,
+		SenderId: senderId,
+	}
+
+	copy(msg.SenderName[:], utils.MakeFixedLengthStringBytes(senderName, 0x15))
+	copy(msg.Message[:], utils.MakeFixedLengthStringBytes(message, 0x64))
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CSay(packet []byte) (*MsgS2CSay, error) {
+	var msg MsgS2CSay
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CChatWindowOpt struct {
+	MsgHead
+	Option uint32
+}
+
+func (msg *MsgS2CChatWindowOpt) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CChatWindowOpt) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CChatWindowOpt) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CChatWindowOpt(pcId uint32, option uint32) *MsgS2CChatWindowOpt {
+	msg := MsgS2CChatWindowOpt{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CChatWindowOpt,
+		},
+		Option: option,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CChatWindowOpt(packet []byte) (*MsgS2CChatWindowOpt, error) {
+	var msg MsgS2CChatWindowOpt
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2MWhisper struct {
+	MsgHeadMs
+	SenderName [0x15]byte
+	TargetName [0x15]byte
+	Message    [0x64]byte
+}
+
+func (msg *MsgS2MWhisper) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2MWhisper) SetSize() {
+	msg.Size = uint16(msg.GetSize())
+}
+
+func (msg *MsgS2MWhisper) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2MWhisper(pcId uint32, senderName string, targetName string, message string, gateServerId byte) *MsgS2MWhisper {
+	msg := MsgS2MWhisper{
+		MsgHeadMs: MsgHeadMs{Protocol: protocol.S2MWhisper, GateServerId: gateServerId, PcId: pcId},
+	}
+
+	copy(msg.SenderName[:], utils.MakeFixedLengthStringBytes(senderName, 0x15))
+	copy(msg.TargetName[:], utils.MakeFixedLengthStringBytes(targetName, 0x15))
+	copy(msg.Message[:], utils.MakeFixedLengthStringBytes(message, 0x64))
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2MWhisper(packet []byte) (*MsgS2MWhisper, error) {
+	var msg MsgS2MWhisper
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CMoveItem struct {
+	MsgHead
+	FromSlot byte
+	ToSlot   byte
+	Result   byte
+}
+
+func (msg *MsgS2CMoveItem) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CMoveItem) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CMoveItem) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CMoveItem(pcId uint32, fromSlot byte, toSlot byte, result byte) *MsgS2CMoveItem {
+	msg := MsgS2CMoveItem{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CMoveItem,
+		},
+		FromSlot: fromSlot,
+		ToSlot:   toSlot,
+		Result:   result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CMoveItem(packet []byte) (*MsgS2CMoveItem, error) {
+	var msg MsgS2CMoveItem
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CWearItem struct {
+	MsgHead
+	Slot      byte
+	WearIndex byte
+	Result    byte
+}
+
+func (msg *MsgS2CWearItem) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CWearItem) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CWearItem) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CWearItem(pcId uint32, slot byte, wearIndex byte, result byte) *MsgS2CWearItem {
+	msg := MsgS2CWearItem{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CWearItem,
+		},
+		Slot:      slot,
+		WearIndex: wearIndex,
+		Result:    result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CWearItem(packet []byte) (*MsgS2CWearItem, error) {
+	var msg MsgS2CWearItem
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CStripItem struct {
+	MsgHead
+	WearIndex byte
+	Slot      byte
+	Result    byte
+}
+
+func (msg *MsgS2CStripItem) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CStripItem) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CStripItem) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CStripItem(pcId uint32, wearIndex byte, slot byte, result byte) *MsgS2CStripItem {
+	msg := MsgS2CStripItem{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CStripItem,
+		},
+		WearIndex: wearIndex,
+		Slot:      slot,
+		Result:    result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CStripItem(packet []byte) (*MsgS2CStripItem, error) {
+	var msg MsgS2CStripItem
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CHsWearItem struct {
+	MsgHead
+	HsId      uint32
+	Slot      byte
+	WearIndex byte
+	Result    byte
+}
+
+func (msg *MsgS2CHsWearItem) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CHsWearItem) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CHsWearItem) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CHsWearItem(pcId uint32, hsId uint32, slot byte, wearIndex byte, result byte) *MsgS2CHsWearItem {
+	msg := MsgS2CHsWearItem{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CHsWearItem,
+		},
+		HsId:      hsId,
+		Slot:      slot,
+		WearIndex: wearIndex,
+		Result:    result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CHsWearItem(packet []byte) (*MsgS2CHsWearItem, error) {
+	var msg MsgS2CHsWearItem
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CHsStripItem struct {
+	MsgHead
+	HsId      uint32
+	WearIndex byte
+	Slot      byte
+	Result    byte
+}
+
+func (msg *MsgS2CHsStripItem) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CHsStripItem) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CHsStripItem) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CHsStripItem(pcId uint32, hsId uint32, wearIndex byte, slot byte, result byte) *MsgS2CHsStripItem {
+	msg := MsgS2CHsStripItem{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CHsStripItem,
+		},
+		HsId:      hsId,
+		WearIndex: wearIndex,
+		Slot:      slot,
+		Result:    result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CHsStripItem(packet []byte) (*MsgS2CHsStripItem, error) {
+	var msg MsgS2CHsStripItem
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CPcStats struct {
+	MsgHead
+	RemainingPoints       uint16
+	Strength              uint16
+	Intelligence          uint16
+	Dexterity             uint16
+	Vitality              uint16
+	Mana                  uint16
+	HPCapacity            uint16
+	MPCapacity            uint16
+	HP                    uint16
+	MP                    uint16
+	HitAttack             uint16
+	MagicAttack           uint16
+	Defense               uint16
+	FireAttack            uint16
+	FireDefence           uint16
+	IceAttack             uint16
+	IceDefense            uint16
+	LightAttack           uint16
+	LightDefense          uint16
+	MaxHp                 uint16
+	MaxMp                 uint16
+	AdditionalHitAttack   uint16
+	AdditionalMagicAttack uint16
+}
+
+func (msg *MsgS2CPcStats) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CPcStats) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CPcStats) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CPcStats(pcId uint32) *MsgS2CPcStats {
+	msg := MsgS2CPcStats{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CPcStats,
+		},
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CPcStats(packet []byte) (*MsgS2CPcStats, error) {
+	var msg MsgS2CPcStats
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CItemAppear struct {
+	MsgHead
+	ItemId     uint32
+	ItemCode   uint32
+	ItemOption uint32
+	MapCell    uint32
+}
+
+func (msg *MsgS2CItemAppear) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CItemAppear) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CItemAppear) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CItemAppear(pcId uint32, itemId uint32, itemCode uint32, itemOption uint32, mapCell uint32) *MsgS2CItemAppear {
+	msg := MsgS2CItemAppear{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CItemAppear,
+		},
+		ItemId:     itemId,
+		ItemCode:   itemCode,
+		ItemOption: itemOption,
+		MapCell:    mapCell,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CItemAppear(packet []byte) (*MsgS2CItemAppear, error) {
+	var msg MsgS2CItemAppear
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CItemDisappear struct {
+	MsgHead
+	ItemId uint32
+}
+
+func (msg *MsgS2CItemDisappear) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CItemDisappear) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CItemDisappear) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CItemDisappear(pcId uint32, itemId uint32) *MsgS2CItemDisappear {
+	msg := MsgS2CItemDisappear{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CItemDisappear,
+		},
+		ItemId: itemId,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CItemDisappear(packet []byte) (*MsgS2CItemDisappear, error) {
+	var msg MsgS2CItemDisappear
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CPickupItem struct {
+	MsgHead
+	ItemId uint32
+	Slot   byte
+	Result byte
+}
+
+func (msg *MsgS2CPickupItem) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CPickupItem) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CPickupItem) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CPickupItem(pcId uint32, itemId uint32, slot byte, result byte) *MsgS2CPickupItem {
+	msg := MsgS2CPickupItem{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CPickupItem,
+		},
+		ItemId: itemId,
+		Slot:   slot,
+		Result: result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CPickupItem(packet []byte) (*MsgS2CPickupItem, error) {
+	var msg MsgS2CPickupItem
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CDropItem struct {
+	MsgHead
+	Slot   byte
+	Result byte
+}
+
+func (msg *MsgS2CDropItem) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CDropItem) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CDropItem) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CDropItem(pcId uint32, slot byte, result byte) *MsgS2CDropItem {
+	msg := MsgS2CDropItem{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CDropItem,
+		},
+		Slot:   slot,
+		Result: result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CDropItem(packet []byte) (*MsgS2CDropItem, error) {
+	var msg MsgS2CDropItem
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CBuyItem struct {
+	MsgHead
+	NpcId          uint32
+	ItemCode       uint32
+	ItemOption     uint32
+	ItemUniqueCode uint32
+	Slot           byte
+	Woonz          uint32
+	Result         byte
+}
+
+func (msg *MsgS2CBuyItem) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CBuyItem) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CBuyItem) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CBuyItem(pcId uint32, npcId uint32, itemCode uint32, itemOption uint32, itemUniqueCode uint32, slot byte, woonz uint32, result byte) *MsgS2CBuyItem {
+	msg := MsgS2CBuyItem{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CBuyItem,
+		},
+		NpcId:          npcId,
+		ItemCode:       itemCode,
+		ItemOption:     itemOption,
+		ItemUniqueCode: itemUniqueCode,
+		Slot:           slot,
+		Woonz:          woonz,
+		Result:         result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CBuyItem(packet []byte) (*MsgS2CBuyItem, error) {
+	var msg MsgS2CBuyItem
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CSellItem struct {
+	MsgHead
+	NpcId  uint32
+	Slot   byte
+	Woonz  uint32
+	Result byte
+}
+
+func (msg *MsgS2CSellItem) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CSellItem) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CSellItem) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CSellItem(pcId uint32, npcId uint32, slot byte, woonz uint32, result byte) *MsgS2CSellItem {
+	msg := MsgS2CSellItem{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CSellItem,
+		},
+		NpcId:  npcId,
+		Slot:   slot,
+		Woonz:  woonz,
+		Result: result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CSellItem(packet []byte) (*MsgS2CSellItem, error) {
+	var msg MsgS2CSellItem
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CAskDeal struct {
+	MsgHead
+	RequesterId   uint32
+	RequesterName [0x15]byte
+}
+
+func (msg *MsgS2CAskDeal) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CAskDeal) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CAskDeal) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CAskDeal(pcId uint32, requesterId uint32, requesterName string) *MsgS2CAskDeal {
+	msg := MsgS2CAskDeal{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CAskDeal,
+		},
+		RequesterId: requesterId,
+	}
+
+	copy(msg.RequesterName[:], utils.MakeFixedLengthStringBytes(requesterName, 0x15))
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CAskDeal(packet []byte) (*MsgS2CAskDeal, error) {
+	var msg MsgS2CAskDeal
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CAnsDeal struct {
+	MsgHead
+	PartnerId uint32
+	Result    byte
+}
+
+func (msg *MsgS2CAnsDeal) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CAnsDeal) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CAnsDeal) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CAnsDeal(pcId uint32, partnerId uint32, result byte) *MsgS2CAnsDeal {
+	msg := MsgS2CAnsDeal{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CAnsDeal,
+		},
+		PartnerId: partnerId,
+		Result:    result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CAnsDeal(packet []byte) (*MsgS2CAnsDeal, error) {
+	var msg MsgS2CAnsDeal
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CPutInItem struct {
+	MsgHead
+	OwnerId        uint32
+	Slot           byte
+	ItemCode       uint32
+	ItemOption     uint32
+	ItemUniqueCode uint32
+}
+
+func (msg *MsgS2CPutInItem) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CPutInItem) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CPutInItem) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CPutInItem(pcId uint32, ownerId uint32, slot byte, itemCode uint32, itemOption uint32, itemUniqueCode uint32) *MsgS2CPutInItem {
+	msg := MsgS2CPutInItem{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CPutInItem,
+		},
+		OwnerId:        ownerId,
+		Slot:           slot,
+		ItemCode:       itemCode,
+		ItemOption:     itemOption,
+		ItemUniqueCode: itemUniqueCode,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CPutInItem(packet []byte) (*MsgS2CPutInItem, error) {
+	var msg MsgS2CPutInItem
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CPutOutItem struct {
+	MsgHead
+	OwnerId uint32
+	Slot    byte
+}
+
+func (msg *MsgS2CPutOutItem) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CPutOutItem) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CPutOutItem) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CPutOutItem(pcId uint32, ownerId uint32, slot byte) *MsgS2CPutOutItem {
+	msg := MsgS2CPutOutItem{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CPutOutItem,
+		},
+		OwnerId: ownerId,
+		Slot:    slot,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CPutOutItem(packet []byte) (*MsgS2CPutOutItem, error) {
+	var msg MsgS2CPutOutItem
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CDecideDeal struct {
+	MsgHead
+	OwnerId uint32
+	Woonz   uint32
+}
+
+func (msg *MsgS2CDecideDeal) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CDecideDeal) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CDecideDeal) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CDecideDeal(pcId uint32, ownerId uint32, woonz uint32) *MsgS2CDecideDeal {
+	msg := MsgS2CDecideDeal{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CDecideDeal,
+		},
+		OwnerId: ownerId,
+		Woonz:   woonz,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CDecideDeal(packet []byte) (*MsgS2CDecideDeal, error) {
+	var msg MsgS2CDecideDeal
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CConfirmDeal struct {
+	MsgHead
+	Result byte
+}
+
+func (msg *MsgS2CConfirmDeal) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CConfirmDeal) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CConfirmDeal) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CConfirmDeal(pcId uint32, result byte) *MsgS2CConfirmDeal {
+	msg := MsgS2CConfirmDeal{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CConfirmDeal,
+		},
+		Result: result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CConfirmDeal(packet []byte) (*MsgS2CConfirmDeal, error) {
+	var msg MsgS2CConfirmDeal
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2COpenStorage struct {
+	MsgHead
+	Woonz        uint32
+	Result       byte
+	StorageItems [0x28]CharacterInventory
+}
+
+func (msg *MsgS2COpenStorage) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2COpenStorage) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2COpenStorage) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2COpenStorage(pcId uint32, woonz uint32, result byte) *MsgS2COpenStorage {
+	msg := MsgS2COpenStorage{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2COpenStorage,
+		},
+		Woonz:  woonz,
+		Result: result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2COpenStorage(packet []byte) (*MsgS2COpenStorage, error) {
+	var msg MsgS2COpenStorage
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CInven2Storage struct {
+	MsgHead
+	InventorySlot byte
+	StorageSlot   byte
+	Result        byte
+}
+
+func (msg *MsgS2CInven2Storage) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CInven2Storage) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CInven2Storage) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CInven2Storage(pcId uint32, inventorySlot byte, storageSlot byte, result byte) *MsgS2CInven2Storage {
+	msg := MsgS2CInven2Storage{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CInven2Storage,
+		},
+		InventorySlot: inventorySlot,
+		StorageSlot:   storageSlot,
+		Result:        result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CInven2Storage(packet []byte) (*MsgS2CInven2Storage, error) {
+	var msg MsgS2CInven2Storage
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CStorage2Inven struct {
+	MsgHead
+	StorageSlot   byte
+	InventorySlot byte
+	Result        byte
+}
+
+func (msg *MsgS2CStorage2Inven) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CStorage2Inven) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CStorage2Inven) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CStorage2Inven(pcId uint32, storageSlot byte, inventorySlot byte, result byte) *MsgS2CStorage2Inven {
+	msg := MsgS2CStorage2Inven{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CStorage2Inven,
+		},
+		StorageSlot:   storageSlot,
+		InventorySlot: inventorySlot,
+		Result:        result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CStorage2Inven(packet []byte) (*MsgS2CStorage2Inven, error) {
+	var msg MsgS2CStorage2Inven
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CMoveItemInStorage struct {
+	MsgHead
+	FromSlot byte
+	ToSlot   byte
+	Result   byte
+}
+
+func (msg *MsgS2CMoveItemInStorage) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CMoveItemInStorage) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CMoveItemInStorage) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CMoveItemInStorage(pcId uint32, fromSlot byte, toSlot byte, result byte) *MsgS2CMoveItemInStorage {
+	msg := MsgS2CMoveItemInStorage{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CMoveItemInStorage,
+		},
+		FromSlot: fromSlot,
+		ToSlot:   toSlot,
+		Result:   result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CMoveItemInStorage(packet []byte) (*MsgS2CMoveItemInStorage, error) {
+	var msg MsgS2CMoveItemInStorage
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CDepositeMoney struct {
+	MsgHead
+	Woonz        uint32
+	StorageWoonz uint32
+	Result       byte
+}
+
+func (msg *MsgS2CDepositeMoney) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CDepositeMoney) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CDepositeMoney) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CDepositeMoney(pcId uint32, woonz uint32, storageWoonz uint32, result byte) *MsgS2CDepositeMoney {
+	msg := MsgS2CDepositeMoney{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CDepositeMoney,
+		},
+		Woonz:        woonz,
+		StorageWoonz: storageWoonz,
+		Result:       result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CDepositeMoney(packet []byte) (*MsgS2CDepositeMoney, error) {
+	var msg MsgS2CDepositeMoney
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CWithdrawMoney struct {
+	MsgHead
+	Woonz        uint32
+	StorageWoonz uint32
+	Result       byte
+}
+
+func (msg *MsgS2CWithdrawMoney) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CWithdrawMoney) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CWithdrawMoney) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CWithdrawMoney(pcId uint32, woonz uint32, storageWoonz uint32, result byte) *MsgS2CWithdrawMoney {
+	msg := MsgS2CWithdrawMoney{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CWithdrawMoney,
+		},
+		Woonz:        woonz,
+		StorageWoonz: storageWoonz,
+		Result:       result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CWithdrawMoney(packet []byte) (*MsgS2CWithdrawMoney, error) {
+	var msg MsgS2CWithdrawMoney
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CUsePotion struct {
+	MsgHead
+	Slot       byte
+	ItemOption uint32
+	HP         uint16
+	MP         uint16
+	Result     byte
+}
+
+func (msg *MsgS2CUsePotion) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CUsePotion) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CUsePotion) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CUsePotion(pcId uint32, slot byte, itemOption uint32, hp uint16, mp uint16, result byte) *MsgS2CUsePotion {
+	msg := MsgS2CUsePotion{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CUsePotion,
+		},
+		Slot:       slot,
+		ItemOption: itemOption,
+		HP:         hp,
+		MP:         mp,
+		Result:     result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CUsePotion(packet []byte) (*MsgS2CUsePotion, error) {
+	var msg MsgS2CUsePotion
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CUseItem struct {
+	MsgHead
+	Slot       byte
+	ItemOption uint32
+	Result     byte
+}
+
+func (msg *MsgS2CUseItem) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CUseItem) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CUseItem) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CUseItem(pcId uint32, slot byte, itemOption uint32, result byte) *MsgS2CUseItem {
+	msg := MsgS2CUseItem{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CUseItem,
+		},
+		Slot:       slot,
+		ItemOption: itemOption,
+		Result:     result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CUseItem(packet []byte) (*MsgS2CUseItem, error) {
+	var msg MsgS2CUseItem
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CLearnSkill struct {
+	MsgHead
+	Slot       byte
+	SkillId    byte
+	SkillLevel byte
+	Result     byte
+}
+
+func (msg *MsgS2CLearnSkill) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CLearnSkill) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CLearnSkill) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CLearnSkill(pcId uint32, slot byte, skillId byte, skillLevel byte, result byte) *MsgS2CLearnSkill {
+	msg := MsgS2CLearnSkill{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CLearnSkill,
+		},
+		Slot:       slot,
+		SkillId:    skillId,
+		SkillLevel: skillLevel,
+		Result:     result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CLearnSkill(packet []byte) (*MsgS2CLearnSkill, error) {
+	var msg MsgS2CLearnSkill
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CSkill struct {
+	MsgHead
+	CasterId   uint32
+	TargetId   uint32
+	SkillId    byte
+	SkillLevel byte
+}
+
+func (msg *MsgS2CSkill) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CSkill) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CSkill) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CSkill(pcId uint32, casterId uint32, targetId uint32, skillId byte, skillLevel byte) *MsgS2CSkill {
+	msg := MsgS2CSkill{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CSkill,
+		},
+		CasterId:   casterId,
+		TargetId:   targetId,
+		SkillId:    skillId,
+		SkillLevel: skillLevel,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CSkill(packet []byte) (*MsgS2CSkill, error) {
+	var msg MsgS2CSkill
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CSkillSlotInfo struct {
+	MsgHead
+	SkillSlots [0x14]byte
+}
+
+func (msg *MsgS2CSkillSlotInfo) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CSkillSlotInfo) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CSkillSlotInfo) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CSkillSlotInfo(pcId uint32, skillSlots [0x14]byte) *MsgS2CSkillSlotInfo {
+	msg := MsgS2CSkillSlotInfo{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CSkillSlotInfo,
+		},
+		SkillSlots: skillSlots,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CSkillSlotInfo(packet []byte) (*MsgS2CSkillSlotInfo, error) {
+	var msg MsgS2CSkillSlotInfo
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CLearnPskill struct {
+	MsgHead
+	SkillId    byte
+	SkillLevel byte
+	Lore       uint32
+	Result     byte
+}
+
+func (msg *MsgS2CLearnPskill) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CLearnPskill) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CLearnPskill) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CLearnPskill(pcId uint32, skillId byte, skillLevel byte, lore uint32, result byte) *MsgS2CLearnPskill {
+	msg := MsgS2CLearnPskill{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CLearnPskill,
+		},
+		SkillId:    skillId,
+		SkillLevel: skillLevel,
+		Lore:       lore,
+		Result:     result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CLearnPskill(packet []byte) (*MsgS2CLearnPskill, error) {
+	var msg MsgS2CLearnPskill
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CForgetAllPskill struct {
+	MsgHead
+	Lore   uint32
+	Result byte
+}
+
+func (msg *MsgS2CForgetAllPskill) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CForgetAllPskill) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CForgetAllPskill) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CForgetAllPskill(pcId uint32, lore uint32, result byte) *MsgS2CForgetAllPskill {
+	msg := MsgS2CForgetAllPskill{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CForgetAllPskill,
+		},
+		Lore:   lore,
+		Result: result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CForgetAllPskill(packet []byte) (*MsgS2CForgetAllPskill, error) {
+	var msg MsgS2CForgetAllPskill
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CRetrievePoint struct {
+	MsgHead
+	Stat   byte
+	Points uint16
+	Woonz  uint32
+	Result byte
+}
+
+func (msg *MsgS2CRetrievePoint) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CRetrievePoint) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CRetrievePoint) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CRetrievePoint(pcId uint32, stat byte, points uint16, woonz uint32, result byte) *MsgS2CRetrievePoint {
+	msg := MsgS2CRetrievePoint{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CRetrievePoint,
+		},
+		Stat:   stat,
+		Points: points,
+		Woonz:  woonz,
+		Result: result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CRetrievePoint(packet []byte) (*MsgS2CRetrievePoint, error) {
+	var msg MsgS2CRetrievePoint
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CHsAllotPoint struct {
+	MsgHead
+	HsId            uint32
+	Stat            byte
+	Points          uint16
+	RemainingPoints uint16
+	Result          byte
+}
+
+func (msg *MsgS2CHsAllotPoint) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CHsAllotPoint) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CHsAllotPoint) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CHsAllotPoint(pcId uint32, hsId uint32, stat byte, points uint16, remainingPoints uint16, result byte) *MsgS2CHsAllotPoint {
+	msg := MsgS2CHsAllotPoint{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CHsAllotPoint,
+		},
+		HsId:            hsId,
+		Stat:            stat,
+		Points:          points,
+		RemainingPoints: remainingPoints,
+		Result:          result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CHsAllotPoint(packet []byte) (*MsgS2CHsAllotPoint, error) {
+	var msg MsgS2CHsAllotPoint
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CHsRetrievePoint struct {
+	MsgHead
+	HsId            uint32
+	Stat            byte
+	Points          uint16
+	RemainingPoints uint16
+	Woonz           uint32
+	Result          byte
+}
+
+func (msg *MsgS2CHsRetrievePoint) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CHsRetrievePoint) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CHsRetrievePoint) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CHsRetrievePoint(pcId uint32, hsId uint32, stat byte, points uint16, remainingPoints uint16, woonz uint32, result byte) *MsgS2CHsRetrievePoint {
+	msg := MsgS2CHsRetrievePoint{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CHsRetrievePoint,
+		},
+		HsId:            hsId,
+		Stat:            stat,
+		Points:          points,
+		RemainingPoints: remainingPoints,
+		Woonz:           woonz,
+		Result:          result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CHsRetrievePoint(packet []byte) (*MsgS2CHsRetrievePoint, error) {
+	var msg MsgS2CHsRetrievePoint
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CAskParty struct {
+	MsgHead
+	RequesterId   uint32
+	RequesterName [0x15]byte
+}
+
+func (msg *MsgS2CAskParty) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CAskParty) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CAskParty) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CAskParty(pcId uint32, requesterId uint32, requesterName string) *MsgS2CAskParty {
+	msg := MsgS2CAskParty{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CAskParty,
+		},
+		RequesterId: requesterId,
+	}
+
+	copy(msg.RequesterName[:], utils.MakeFixedLengthStringBytes(requesterName, 0x15))
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CAskParty(packet []byte) (*MsgS2CAskParty, error) {
+	var msg MsgS2CAskParty
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CAnsParty struct {
+	MsgHead
+	PartnerId uint32
+	Result    byte
+}
+
+func (msg *MsgS2CAnsParty) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CAnsParty) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CAnsParty) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CAnsParty(pcId uint32, partnerId uint32, result byte) *MsgS2CAnsParty {
+	msg := MsgS2CAnsParty{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CAnsParty,
+		},
+		PartnerId: partnerId,
+		Result:    result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CAnsParty(packet []byte) (*MsgS2CAnsParty, error) {
+	var msg MsgS2CAnsParty
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CPartyInfo struct {
+	MsgHead
+	LeaderId    uint32
+	MemberCount byte
+	Members     [0x08]PartyMember
+}
+
+func (msg *MsgS2CPartyInfo) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CPartyInfo) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CPartyInfo) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CPartyInfo(pcId uint32, leaderId uint32) *MsgS2CPartyInfo {
+	msg := MsgS2CPartyInfo{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CPartyInfo,
+		},
+		LeaderId: leaderId,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CPartyInfo(packet []byte) (*MsgS2CPartyInfo, error) {
+	var msg MsgS2CPartyInfo
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CPartyMemberUpdate struct {
+	MsgHead
+	Member PartyMember
+}
+
+func (msg *MsgS2CPartyMemberUpdate) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CPartyMemberUpdate) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CPartyMemberUpdate) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CPartyMemberUpdate(pcId uint32, member PartyMember) *MsgS2CPartyMemberUpdate {
+	msg := MsgS2CPartyMemberUpdate{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CPartyMemberUpdate,
+		},
+		Member: member,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CPartyMemberUpdate(packet []byte) (*MsgS2CPartyMemberUpdate, error) {
+	var msg MsgS2CPartyMemberUpdate
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2MAskParty struct {
+	MsgHeadMs
+	TargetId uint32
+}
+
+func (msg *MsgS2MAskParty) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2MAskParty) SetSize() {
+	msg.Size = uint16(msg.GetSize())
+}
+
+func (msg *MsgS2MAskParty) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2MAskParty(pcId uint32, targetId uint32, gateServerId byte) *MsgS2MAskParty {
+	msg := MsgS2MAskParty{
+		MsgHeadMs: MsgHeadMs{Protocol: protocol.S2MAskParty, GateServerId: gateServerId, PcId: pcId},
+		TargetId:  targetId,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2MAskParty(packet []byte) (*MsgS2MAskParty, error) {
+	var msg MsgS2MAskParty
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2MAnsParty struct {
+	MsgHeadMs
+	RequesterId uint32
+	Accept      byte
+}
+
+func (msg *MsgS2MAnsParty) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2MAnsParty) SetSize() {
+	msg.Size = uint16(msg.GetSize())
+}
+
+func (msg *MsgS2MAnsParty) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2MAnsParty(pcId uint32, requesterId uint32, accept byte, gateServerId byte) *MsgS2MAnsParty {
+	msg := MsgS2MAnsParty{
+		MsgHeadMs:   MsgHeadMs{Protocol: protocol.S2MAnsParty, GateServerId: gateServerId, PcId: pcId},
+		RequesterId: requesterId,
+		Accept:      accept,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2MAnsParty(packet []byte) (*MsgS2MAnsParty, error) {
+	var msg MsgS2MAnsParty
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2MOutParty struct {
+	MsgHeadMs
+	TargetId uint32
+}
+
+func (msg *MsgS2MOutParty) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2MOutParty) SetSize() {
+	msg.Size = uint16(msg.GetSize())
+}
+
+func (msg *MsgS2MOutParty) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2MOutParty(pcId uint32, targetId uint32, gateServerId byte) *MsgS2MOutParty {
+	msg := MsgS2MOutParty{
+		MsgHeadMs: MsgHeadMs{Protocol: protocol.S2MOutParty, GateServerId: gateServerId, PcId: pcId},
+		TargetId:  targetId,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2MOutParty(packet []byte) (*MsgS2MOutParty, error) {
+	var msg MsgS2MOutParty
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2MPartyMemberUpdate struct {
+	MsgHeadMs
+	Entered byte
+	Level   uint16
+	HP      uint16
+	MaxHp   uint16
+	MapId   uint16
+	MapCell uint32
+}
+
+func (msg *MsgS2MPartyMemberUpdate) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2MPartyMemberUpdate) SetSize() {
+	msg.Size = uint16(msg.GetSize())
+}
+
+func (msg *MsgS2MPartyMemberUpdate) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2MPartyMemberUpdate(pcId uint32, entered byte, level uint16, hp uint16, maxHp uint16, mapId uint16, mapCell uint32, gateServerId byte) *MsgS2MPartyMemberUpdate {
+	msg := MsgS2MPartyMemberUpdate{
+		MsgHeadMs: MsgHeadMs{Protocol: protocol.S2MPartyMemberUpdate, GateServerId: gateServerId, PcId: pcId},
+		Entered:   entered,
+		Level:     level,
+		HP:        hp,
+		MaxHp:     maxHp,
+		MapId:     mapId,
+		MapCell:   mapCell,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2MPartyMemberUpdate(packet []byte) (*MsgS2MPartyMemberUpdate, error) {
+	var msg MsgS2MPartyMemberUpdate
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CClan struct {
+	MsgHead
+	Command  byte
+	Result   byte
+	ClanId   uint32
+	Rank     byte
+	ClanName [0x15]byte
+}
+
+func (msg *MsgS2CClan) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CClan) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CClan) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CClan(pcId uint32, command byte, result byte, clanId uint32, rank byte, clanName string) *MsgS2CClan {
+	msg := MsgS2CClan{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CClan,
+		},
+		Command: command,
+		Result:  result,
+		ClanId:  clanId,
+		Rank:    rank,
+	}
+
+	copy(msg.ClanName[:], utils.MakeFixedLengthStringBytes(clanName, 0x15))
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CClan(packet []byte) (*MsgS2CClan, error) {
+	var msg MsgS2CClan
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CJoinClan struct {
+	MsgHead
+	RequesterId uint32
+	ClanId      uint32
+	ClanName    [0x15]byte
+}
+
+func (msg *MsgS2CJoinClan) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CJoinClan) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CJoinClan) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CJoinClan(pcId uint32, requesterId uint32, clanId uint32, clanName string) *MsgS2CJoinClan {
+	msg := MsgS2CJoinClan{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CJoinClan,
+		},
+		RequesterId: requesterId,
+		ClanId:      clanId,
+	}
+
+	copy(msg.ClanName[:], utils.MakeFixedLengthStringBytes(clanName, 0x15))
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CJoinClan(packet []byte) (*MsgS2CJoinClan, error) {
+	var msg MsgS2CJoinClan
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CAnsClan struct {
+	MsgHead
+	PartnerId uint32
+	Result    byte
+}
+
+func (msg *MsgS2CAnsClan) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CAnsClan) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CAnsClan) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CAnsClan(pcId uint32, partnerId uint32, result byte) *MsgS2CAnsClan {
+	msg := MsgS2CAnsClan{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CAnsClan,
+		},
+		PartnerId: partnerId,
+		Result:    result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CAnsClan(packet []byte) (*MsgS2CAnsClan, error) {
+	var msg MsgS2CAnsClan
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CBoltClan struct {
+	MsgHead
+	TargetId uint32
+	Result   byte
+}
+
+func (msg *MsgS2CBoltClan) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CBoltClan) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CBoltClan) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CBoltClan(pcId uint32, targetId uint32, result byte) *MsgS2CBoltClan {
+	msg := MsgS2CBoltClan{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CBoltClan,
+		},
+		TargetId: targetId,
+		Result:   result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CBoltClan(packet []byte) (*MsgS2CBoltClan, error) {
+	var msg MsgS2CBoltClan
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2CClanInfo struct {
+	MsgHead
+	ClanId      uint32
+	ClanName    [0x15]byte
+	MemberCount byte
+	Members     [0x32]ClanMember
+}
+
+func (msg *MsgS2CClanInfo) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2CClanInfo) SetSize() {
+	msg.Size = msg.GetSize()
+}
+
+func (msg *MsgS2CClanInfo) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2CClanInfo(pcId uint32, clanId uint32, clanName string) *MsgS2CClanInfo {
+	msg := MsgS2CClanInfo{
+		MsgHead: MsgHead{
+			MsgHeadNoProtocol: MsgHeadNoProtocol{
+				PcId: pcId,
+				Ctrl: 0x03,
+				Cmd:  0xFF,
+			},
+			Protocol: protocol.S2CClanInfo,
+		},
+		ClanId: clanId,
+	}
+
+	copy(msg.ClanName[:], utils.MakeFixedLengthStringBytes(clanName, 0x15))
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2CClanInfo(packet []byte) (*MsgS2CClanInfo, error) {
+	var msg MsgS2CClanInfo
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2MJoinClan struct {
+	MsgHeadMs
+	TargetId             uint32
+	ClanId               uint32
+	ClanName             [0x15]byte
+	RequesterCharacterId uint32
+}
+
+func (msg *MsgS2MJoinClan) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2MJoinClan) SetSize() {
+	msg.Size = uint16(msg.GetSize())
+}
+
+func (msg *MsgS2MJoinClan) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2MJoinClan(pcId uint32, targetId uint32, clanId uint32, clanName string, requesterCharacterId uint32, gateServerId byte) *MsgS2MJoinClan {
+	msg := MsgS2MJoinClan{
+		MsgHeadMs:            MsgHeadMs{Protocol: protocol.S2MJoinClan, GateServerId: gateServerId, PcId: pcId},
+		TargetId:             targetId,
+		ClanId:               clanId,
+		RequesterCharacterId: requesterCharacterId,
+	}
+
+	copy(msg.ClanName[:], utils.MakeFixedLengthStringBytes(clanName, 0x15))
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2MJoinClan(packet []byte) (*MsgS2MJoinClan, error) {
+	var msg MsgS2MJoinClan
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2MAnsClan struct {
+	MsgHeadMs
+	RequesterId uint32
+	Result      byte
+}
+
+func (msg *MsgS2MAnsClan) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2MAnsClan) SetSize() {
+	msg.Size = uint16(msg.GetSize())
+}
+
+func (msg *MsgS2MAnsClan) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2MAnsClan(pcId uint32, requesterId uint32, result byte, gateServerId byte) *MsgS2MAnsClan {
+	msg := MsgS2MAnsClan{
+		MsgHeadMs:   MsgHeadMs{Protocol: protocol.S2MAnsClan, GateServerId: gateServerId, PcId: pcId},
+		RequesterId: requesterId,
+		Result:      result,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2MAnsClan(packet []byte) (*MsgS2MAnsClan, error) {
+	var msg MsgS2MAnsClan
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2MClanUpdate struct {
+	MsgHeadMs
+	TargetId uint32
+}
+
+func (msg *MsgS2MClanUpdate) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2MClanUpdate) SetSize() {
+	msg.Size = uint16(msg.GetSize())
+}
+
+func (msg *MsgS2MClanUpdate) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2MClanUpdate(pcId uint32, targetId uint32, gateServerId byte) *MsgS2MClanUpdate {
+	msg := MsgS2MClanUpdate{
+		MsgHeadMs: MsgHeadMs{Protocol: protocol.S2MClanUpdate, GateServerId: gateServerId, PcId: pcId},
+		TargetId:  targetId,
+	}
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2MClanUpdate(packet []byte) (*MsgS2MClanUpdate, error) {
+	var msg MsgS2MClanUpdate
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+type MsgS2MNationChat struct {
+	MsgHeadMs
+	Nation     byte
+	SenderName [0x15]byte
+	Message    [0x64]byte
+}
+
+func (msg *MsgS2MNationChat) GetSize() uint32 {
+	return uint32(binary.Size(msg))
+}
+
+func (msg *MsgS2MNationChat) SetSize() {
+	msg.Size = uint16(msg.GetSize())
+}
+
+func (msg *MsgS2MNationChat) GetBytes() []byte {
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, msg)
+	return buffer.Bytes()
+}
+
+func NewMsgS2MNationChat(pcId uint32, nation byte, senderName string, message string, gateServerId byte) *MsgS2MNationChat {
+	msg := MsgS2MNationChat{
+		MsgHeadMs: MsgHeadMs{Protocol: protocol.S2MNationChat, GateServerId: gateServerId, PcId: pcId},
+		Nation:    nation,
+	}
+
+	copy(msg.SenderName[:], utils.MakeFixedLengthStringBytes(senderName, 0x15))
+	copy(msg.Message[:], utils.MakeFixedLengthStringBytes(message, 0x64))
+	msg.SetSize()
+	return &msg
+}
+
+func ReadMsgS2MNationChat(packet []byte) (*MsgS2MNationChat, error) {
+	var msg MsgS2MNationChat
+	if err := binary.Read(bytes.NewReader(packet), binary.LittleEndian, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
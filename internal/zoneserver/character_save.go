@@ -0,0 +1,411 @@
+package zoneserver
+
+import (
+	"errors"
+	"time"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/zoneserver/db"
+)
+
+// saveResultPostTimeout is how long posting the result of a save waits for
+// room in a full task queue before the wait is logged and started again.
+const saveResultPostTimeout = time.Second
+
+// playerSaveState tracks whether a player has unsaved changes and serializes
+// saves so that each one is made against the version the previous returned.
+type playerSaveState struct {
+	dirty     bool
+	saving    bool
+	pending   bool
+	callbacks []func(error)
+	queued    []func(error)
+}
+
+func (p *Player) MarkDirty() {
+	p.save.dirty = true
+}
+
+// savePlayer writes the player to the database off the zone goroutine. done,
+// if not nil, is called on the zone goroutine once the save has finished. A
+// save requested while another is running is made right after it.
+func (z *Zone) savePlayer(player *Player, done func(error)) {
+	if player.save.saving {
+		player.save.pending = true
+		if done != nil {
+			player.save.queued = append(player.save.queued, done)
+		}
+
+		return
+	}
+
+	player.save.saving = true
+	player.save.dirty = false
+	if done != nil {
+		player.save.callbacks = append(player.save.callbacks, done)
+	}
+
+	characterId, version, level, exp := player.CharacterId, player.CharacterVersion, player.Level, player.Exp
+	characterData := z.newCharacterData(player)
+	go func() {
+		newVersion, err := z.db.SaveCharacter(characterId, version, level, exp, characterData)
+		z.postSaveResult(player, func(zone *Zone) { zone.finishPlayerSave(player, newVersion, err) })
+	}()
+}
+
+// postSaveResult hands the result of a save or reload made off the zone
+// goroutine to the zone the player is in once it is done. A result that is
+// never run would leave the player marked as saving for good, holding back
+// every later save and the callbacks waiting on this one, so while the task
+// queue is full the result keeps waiting for room until the zone stops.
+func (z *Zone) postSaveResult(player *Player, result func(zone *Zone)) {
+	task := func() {
+		if player.Zone == nil || player.Zone == z {
+			result(z)
+			return
+		}
+
+		// The player changed zones on this server while the save was
+		// running, so the result belongs to the zone they are in now.
+		go player.Zone.postSaveResult(player, result)
+	}
+	for !z.taskQueue.TryEnqueue(task, saveResultPostTimeout) {
+		if !z.isRunning.Load() {
+			return
+		}
+
+		z.logger.Error(
+			"Failed to post character save result, retrying",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+	}
+}
+
+func (z *Zone) finishPlayerSave(player *Player, newVersion uint64, err error) {
+	player.save.saving = false
+	switch {
+	case errors.Is(err, db.ErrCharacterVersionConflict):
+		z.logger.Error(
+			"Character save rejected due to version conflict",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "characterId", Value: player.CharacterId},
+			shared.Field{Key: "version", Value: player.CharacterVersion},
+		)
+		player.save.pending = false
+		player.save.callbacks = append(player.save.callbacks, player.save.queued...)
+		player.save.queued = nil
+	case errors.Is(err, db.ErrStorageVersionConflict):
+		player.save.dirty = true
+		z.logger.Error(
+			"Character save rolled back due to storage version conflict",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "characterId", Value: player.CharacterId},
+		)
+	case err != nil:
+		player.save.dirty = true
+		z.logger.Error(
+			"Failed to save character",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "characterId", Value: player.CharacterId},
+		)
+	default:
+		player.CharacterVersion = newVersion
+	}
+
+	callbacks := player.save.callbacks
+	player.save.callbacks = nil
+	for _, callback := range callbacks {
+		callback(err)
+	}
+
+	if errors.Is(err, db.ErrCharacterVersionConflict) {
+		z.reloadPlayer(player)
+		return
+	}
+
+	z.startPendingSave(player)
+}
+
+// startPendingSave makes the save requested while another save or a reload
+// was running.
+func (z *Zone) startPendingSave(player *Player) {
+	if !player.save.pending {
+		return
+	}
+
+	queued := player.save.queued
+	player.save.pending = false
+	player.save.queued = nil
+	player.save.callbacks = queued
+	z.savePlayer(player, nil)
+}
+
+// reloadPlayer replaces the player with the character row after a save lost
+// to another writer. Every later save would conflict with the version the
+// player holds, so the changes made since the last save are dropped and the
+// client is sent the reloaded character. The player stays where it is. Saves
+// requested during the reload are made against the reloaded version.
+func (z *Zone) reloadPlayer(player *Player) {
+	if player.State != PlayerStateInGame || player.save.saving {
+		return
+	}
+
+	player.save.saving = true
+	pcId, characterName := player.PcId, player.CharacterName
+	go func() {
+		character, err := z.db.GetCharacter(pcId, characterName)
+		z.postSaveResult(player, func(zone *Zone) { zone.finishPlayerReload(player, character, err) })
+	}()
+}
+
+func (z *Zone) finishPlayerReload(player *Player, character *db.Character, err error) {
+	player.save.saving = false
+	defer z.startPendingSave(player)
+	if err != nil {
+		z.logger.Error(
+			"Failed to reload character after version conflict",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "characterId", Value: player.CharacterId},
+		)
+		return
+	}
+
+	if player.State != PlayerStateInGame || player.Zone != z {
+		return
+	}
+
+	z.removePlayerFromWorld(player)
+	location := player.Location
+	loadCharacter(player, character, z.zoneManager)
+	player.Location = location
+	player.save.dirty = false
+	z.placeMercenary(player)
+	z.recalculateStats(player)
+	_ = player.Send(z.newWorldLoginMsg(player).GetBytes())
+	_ = player.Send(newPcStatsMsg(player).GetBytes())
+	z.addPlayerToWorld(player)
+	z.logger.Info(
+		"Reloaded character after version conflict",
+		shared.Field{Key: "mapId", Value: z.mapId},
+		shared.Field{Key: "characterId", Value: player.CharacterId},
+		shared.Field{Key: "version", Value: player.CharacterVersion},
+	)
+}
+
+// flushDirtyPlayers saves every player in the zone with unsaved changes and
+// schedules the next flush.
+func (z *Zone) flushDirtyPlayers() {
+	for _, pcId := range z.currentPlayers {
+		player, exists := z.players.Get(pcId)
+		if !exists || player.Zone != z || !player.save.dirty {
+			continue
+		}
+
+		z.savePlayer(player, nil)
+	}
+
+	z.After(z.saveInterval(), z.flushDirtyPlayers)
+}
+
+func (z *Zone) saveInterval() time.Duration {
+	return time.Duration(z.cfg.ZoneSaveInterval) * time.Second
+}
+
+// newCharacterData builds the character data to save from the player,
+// keeping whatever the zone does not track from the data loaded at login.
+func (z *Zone) newCharacterData(player *Player) *db.CharacterData {
+	characterData := player.characterData
+	characterData.Parole = player.Woonz
+	characterData.Lore = player.Lore
+	characterData.ChatWindowOption = player.ChatWindowOption
+	characterData.SocialInfo.Nation = player.SocialInfo.Nation
+	characterData.Location = db.Location{
+		MapCode:  player.Location.MapId,
+		Position: db.Position{X: player.Location.X, Y: player.Location.Y},
+	}
+	characterData.Stats = newStatsData(player.Stats)
+	characterData.Wear = newWearData(player.Wear)
+	characterData.Mercenary = nil
+	if player.Mercenary != nil {
+		characterData.Mercenary = &db.Mercenary{
+			Stats: newStatsData(player.Mercenary.Stats),
+			Wear:  newWearData(player.Mercenary.Wear),
+		}
+	}
+
+	characterData.Inventory = make([]db.InventoryItem, len(player.Inventory))
+	for i, invItem := range player.Inventory {
+		characterData.Inventory[i] = db.InventoryItem{
+			ItemCode:       invItem.ItemCode,
+			ItemOption:     invItem.ItemOption,
+			ItemUniqueCode: invItem.ItemUniqueCode,
+			Slot:           invItem.Slot,
+		}
+	}
+
+	characterData.Skills = make([]db.SkillInfo, len(player.Skills))
+	for i, skill := range player.Skills {
+		characterData.Skills[i] = db.SkillInfo{SkillID: skill.Id, Level: skill.Level}
+	}
+
+	characterData.PassiveSkills = make([]db.SkillInfo, len(player.PassiveSkills))
+	for i, skill := range player.PassiveSkills {
+		characterData.PassiveSkills[i] = db.SkillInfo{SkillID: skill.Id, Level: skill.Level}
+	}
+
+	characterData.SkillSlots = player.SkillSlots
+
+	characterData.ActivePet = db.Pet{
+		PetCode:       player.ActivePet.PetCode,
+		PetHP:         player.ActivePet.PetHP,
+		PetOption:     player.ActivePet.PetOption,
+		PetUniqueCode: player.ActivePet.PetUniqueCode,
+	}
+	characterData.PetInventory = make([]db.PetInventory, len(player.PetInventory))
+	for i, petInv := range player.PetInventory {
+		characterData.PetInventory[i] = db.PetInventory{
+			PetCode:       petInv.Pet.PetCode,
+			PetHP:         petInv.Pet.PetHP,
+			PetOption:     petInv.Pet.PetOption,
+			PetUniqueCode: petInv.Pet.PetUniqueCode,
+			Slot:          petInv.Slot,
+		}
+	}
+
+	return &characterData
+}
+
+func newStatsData(stats Stats) db.Stats {
+	return db.Stats{
+		Strength:        stats.Strength,
+		Intelligence:    stats.Intelligence,
+		Dexterity:       stats.Dexterity,
+		Vitality:        stats.Vitality,
+		Mana:            stats.Mana,
+		RemainingPoints: stats.RemainingPoints,
+		HP:              stats.HP,
+		MP:              stats.MP,
+		HPCapacity:      stats.HPCapacity,
+		MPCapacity:      stats.MPCapacity,
+	}
+}
+
+func newWearData(wear []WearItem) []db.WearItem {
+	wearData := make([]db.WearItem, len(wear))
+	for i, wearItem := range wear {
+		wearData[i] = db.WearItem{
+			ItemCode:       wearItem.ItemCode,
+			ItemOption:     wearItem.ItemOption,
+			ItemUniqueCode: wearItem.ItemUniqueCode,
+		}
+	}
+
+	return wearData
+}
+
+// loadCharacter fills the player from the character row, the reverse of
+// newCharacterData.
+func loadCharacter(player *Player, characterData *db.Character, zoneManager *ZoneManager) {
+	player.Class = characterData.Class
+	player.CharacterId = characterData.ID
+	player.CharacterVersion = characterData.Version
+	player.characterData = characterData.Data
+	player.Level = characterData.Level
+	player.Exp = characterData.Exp
+	player.Lore = characterData.Data.Lore
+	player.Woonz = characterData.Data.Parole
+	player.ChatWindowOption = characterData.Data.ChatWindowOption
+	player.SocialInfo = SocialInfo{
+		Nation: characterData.Data.SocialInfo.Nation,
+	}
+	player.Location = Location{
+		MapId: characterData.Data.Location.MapCode,
+		X:     characterData.Data.Location.Position.X,
+		Y:     characterData.Data.Location.Position.Y,
+	}
+	player.Stats = loadStats(characterData.Data.Stats)
+	player.Wear = loadWear(characterData.Data.Wear, zoneManager)
+	player.Mercenary = nil
+	if characterData.Data.Mercenary != nil {
+		player.Mercenary = &Mercenary{
+			Stats: loadStats(characterData.Data.Mercenary.Stats),
+			Wear:  loadWear(characterData.Data.Mercenary.Wear, zoneManager),
+		}
+	}
+	player.Inventory = make([]InventoryItem, len(characterData.Data.Inventory))
+	for i, invItem := range characterData.Data.Inventory {
+		player.Inventory[i] = InventoryItem{
+			ItemCode:       invItem.ItemCode,
+			ItemOption:     invItem.ItemOption,
+			ItemUniqueCode: invItem.ItemUniqueCode,
+			Slot:           invItem.Slot,
+		}
+	}
+	player.Skills = make([]Skill, len(characterData.Data.Skills))
+	for i, skill := range characterData.Data.Skills {
+		player.Skills[i] = Skill{
+			Id:    skill.SkillID,
+			Level: skill.Level,
+		}
+	}
+	player.PassiveSkills = make([]Skill, len(characterData.Data.PassiveSkills))
+	for i, skill := range characterData.Data.PassiveSkills {
+		player.PassiveSkills[i] = Skill{
+			Id:    skill.SkillID,
+			Level: skill.Level,
+		}
+	}
+	player.SkillSlots = characterData.Data.SkillSlots
+	player.ActivePet = Pet{
+		PetCode:       characterData.Data.ActivePet.PetCode,
+		PetHP:         characterData.Data.ActivePet.PetHP,
+		PetOption:     characterData.Data.ActivePet.PetOption,
+		PetUniqueCode: characterData.Data.ActivePet.PetUniqueCode,
+	}
+	player.PetInventory = make([]PetInventory, len(characterData.Data.PetInventory))
+	for i, petInv := range characterData.Data.PetInventory {
+		player.PetInventory[i] = PetInventory{
+			Pet: Pet{
+				PetCode:       petInv.PetCode,
+				PetHP:         petInv.PetHP,
+				PetOption:     petInv.PetOption,
+				PetUniqueCode: petInv.PetUniqueCode,
+			},
+			Slot: petInv.Slot,
+		}
+	}
+}
+
+func loadStats(stats db.Stats) Stats {
+	return Stats{
+		RemainingPoints: stats.RemainingPoints,
+		Strength:        stats.Strength,
+		Intelligence:    stats.Intelligence,
+		Dexterity:       stats.Dexterity,
+		Vitality:        stats.Vitality,
+		Mana:            stats.Mana,
+		HPCapacity:      stats.HPCapacity,
+		MPCapacity:      stats.MPCapacity,
+		HP:              stats.HP,
+		MP:              stats.MP,
+	}
+}
+
+func loadWear(wearData []db.WearItem, zoneManager *ZoneManager) []WearItem {
+	wear := make([]WearItem, len(wearData))
+	for i, wearItem := range wearData {
+		wear[i] = WearItem{
+			ItemCode:       wearItem.ItemCode,
+			ItemOption:     wearItem.ItemOption,
+			ItemUniqueCode: wearItem.ItemUniqueCode,
+		}
+		if itemData, err := zoneManager.GetItemData(wearItem.ItemCode); err == nil {
+			wear[i].WearIndex = itemData.SlotIndex
+		}
+	}
+
+	return wear
+}
@@ -0,0 +1,39 @@
+package zoneserver
+
+import (
+	"testing"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/zoneserver/db"
+)
+
+func TestMercenarySurvivesSaveAndLoad(t *testing.T) {
+	z := newTestItemZone()
+	player := &Player{
+		Mercenary: &Mercenary{
+			Id:    7,
+			Stats: Stats{Strength: 40, RemainingPoints: 3, HP: 90},
+			Wear:  []WearItem{{ItemCode: testSword, ItemUniqueCode: 11, WearIndex: 0}},
+		},
+	}
+
+	characterData := z.newCharacterData(player)
+	loaded := &Player{}
+	loadCharacter(loaded, &db.Character{Data: *characterData}, z.zoneManager)
+	if loaded.Mercenary == nil {
+		t.Fatal("mercenary was not loaded")
+	}
+
+	if loaded.Mercenary.Stats != player.Mercenary.Stats {
+		t.Fatalf("mercenary stats = %+v, want %+v", loaded.Mercenary.Stats, player.Mercenary.Stats)
+	}
+
+	if len(loaded.Mercenary.Wear) != 1 || loaded.Mercenary.Wear[0] != player.Mercenary.Wear[0] {
+		t.Fatalf("mercenary wear = %v, want %v", loaded.Mercenary.Wear, player.Mercenary.Wear)
+	}
+
+	player.Mercenary = nil
+	loadCharacter(loaded, &db.Character{Data: *z.newCharacterData(player)}, z.zoneManager)
+	if loaded.Mercenary != nil {
+		t.Fatal("a player without a mercenary loaded one")
+	}
+}
@@ -0,0 +1,547 @@
+package zoneserver
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/constants"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages"
+	"github.com/project-agonyl/open-agonyl-servers/internal/utils"
+	"github.com/project-agonyl/open-agonyl-servers/internal/zoneserver/db"
+)
+
+// The commands carried by C2SClan.
+const (
+	clanCommandCreate byte = iota
+	clanCommandSetRank
+	clanCommandDisband
+)
+
+var clanNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{3,21}$`)
+
+// clanInvite is an invitation to a clan waiting for the player's answer.
+type clanInvite struct {
+	requesterId          uint32
+	requesterCharacterId uint32
+	clanId               uint32
+	clanName             string
+}
+
+// handleClan creates, hands over and disbands clans. Only the master may
+// change ranks or disband the clan, and making another member master makes
+// the old master an officer. The rank the player has here only turns away
+// requests early; the database checks it again when making the change.
+func (z *Zone) handleClan(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SClan(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read clan",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	fail := func(result byte) {
+		_ = player.Send(messages.NewMsgS2CClan(
+			player.PcId,
+			msg.Command,
+			result,
+			player.SocialInfo.KHId,
+			player.SocialInfo.KHRank,
+			player.SocialInfo.KHName,
+		).GetBytes())
+	}
+	if player.clanBusy {
+		fail(constants.ClanResultFailure)
+		return
+	}
+
+	switch msg.Command {
+	case clanCommandCreate:
+		z.createClan(player, utils.ReadStringFromBytes(msg.ClanName[:]), fail)
+	case clanCommandSetRank:
+		z.setClanRank(player, msg.TargetId, msg.Rank, fail)
+	case clanCommandDisband:
+		z.disbandClan(player, fail)
+	default:
+		fail(constants.ClanResultFailure)
+	}
+}
+
+func (z *Zone) createClan(player *Player, name string, fail func(byte)) {
+	if player.SocialInfo.KHId != 0 || !clanNamePattern.MatchString(name) {
+		fail(constants.ClanResultFailure)
+		return
+	}
+
+	var clanId uint32
+	characterId := player.CharacterId
+	z.runClanTask(player, func() error {
+		var err error
+		clanId, err = z.db.CreateClan(characterId, name, constants.ClanRankMaster)
+		return err
+	}, func(err error) {
+		switch {
+		case errors.Is(err, db.ErrClanNameTaken):
+			fail(constants.ClanResultNameTaken)
+			return
+		case err != nil:
+			fail(constants.ClanResultFailure)
+			return
+		}
+
+		applyClanMembership(player, &db.ClanMembership{ClanId: clanId, ClanName: name, Rank: constants.ClanRankMaster})
+		_ = player.Send(messages.NewMsgS2CClan(player.PcId, clanCommandCreate, constants.ClanResultSuccess, clanId, constants.ClanRankMaster, name).GetBytes())
+		z.logger.Info(
+			"Clan created",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "pcId", Value: player.PcId},
+			shared.Field{Key: "clanId", Value: clanId},
+			shared.Field{Key: "clanName", Value: name},
+		)
+	})
+}
+
+func (z *Zone) setClanRank(player *Player, targetId uint32, rank byte, fail func(byte)) {
+	if player.SocialInfo.KHRank != constants.ClanRankMaster || targetId == player.PcId ||
+		rank < constants.ClanRankMember || rank > constants.ClanRankMaster {
+		fail(constants.ClanResultFailure)
+		return
+	}
+
+	clanId, characterId := player.SocialInfo.KHId, player.CharacterId
+	z.runClanTask(player, func() error {
+		target, err := z.findClanMember(clanId, targetId)
+		if err != nil {
+			return err
+		}
+
+		ranks := map[uint32]byte{target.CharacterId: rank}
+		if rank == constants.ClanRankMaster {
+			ranks[characterId] = constants.ClanRankOfficer
+		}
+
+		return z.db.SetClanRanks(clanId, characterId, ranks)
+	}, func(err error) {
+		if err != nil {
+			fail(constants.ClanResultFailure)
+			return
+		}
+
+		if rank == constants.ClanRankMaster {
+			player.SocialInfo.KHRank = constants.ClanRankOfficer
+		}
+
+		_ = player.Send(messages.NewMsgS2CClan(
+			player.PcId,
+			clanCommandSetRank,
+			constants.ClanResultSuccess,
+			clanId,
+			player.SocialInfo.KHRank,
+			player.SocialInfo.KHName,
+		).GetBytes())
+		z.notifyClanUpdate(player, targetId)
+	})
+}
+
+func (z *Zone) disbandClan(player *Player, fail func(byte)) {
+	if player.SocialInfo.KHRank != constants.ClanRankMaster {
+		fail(constants.ClanResultFailure)
+		return
+	}
+
+	var members []db.ClanMember
+	clanId, characterId := player.SocialInfo.KHId, player.CharacterId
+	z.runClanTask(player, func() error {
+		var err error
+		members, err = z.db.DisbandClan(clanId, characterId)
+		return err
+	}, func(err error) {
+		if err != nil {
+			fail(constants.ClanResultFailure)
+			return
+		}
+
+		applyClanMembership(player, nil)
+		_ = player.Send(messages.NewMsgS2CClan(player.PcId, clanCommandDisband, constants.ClanResultSuccess, 0, 0, "").GetBytes())
+		for _, member := range members {
+			if member.AccountId != player.PcId {
+				z.notifyClanUpdate(player, member.AccountId)
+			}
+		}
+	})
+}
+
+// handleJoinClan invites another player to the clan. The target may be on
+// another zone server, so the invitation goes through the main server.
+func (z *Zone) handleJoinClan(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SJoinClan(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read join clan",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	if player.SocialInfo.KHRank < constants.ClanRankOfficer || msg.TargetId == player.PcId {
+		_ = player.Send(messages.NewMsgS2CAnsClan(player.PcId, msg.TargetId, constants.ClanResultFailure).GetBytes())
+		return
+	}
+
+	z.sendToMainServer(player, messages.NewMsgS2MJoinClan(
+		player.PcId,
+		msg.TargetId,
+		player.SocialInfo.KHId,
+		player.SocialInfo.KHName,
+		player.CharacterId,
+		player.GateServerSession.agentId,
+	).GetBytes())
+}
+
+func (z *Zone) handleMainServerJoinClan(packet []byte) {
+	msg, err := messages.ReadMsgM2SJoinClan(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read main server join clan",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "mapId", Value: z.mapId},
+		)
+		return
+	}
+
+	player, exists := z.players.Get(msg.PcId)
+	if !exists || player.Zone != z || player.GateServerSession == nil {
+		return
+	}
+
+	if player.SocialInfo.KHId != 0 || player.clanBusy {
+		z.sendToMainServer(player, messages.NewMsgS2MAnsClan(
+			player.PcId,
+			msg.RequesterId,
+			constants.ClanResultFailure,
+			player.GateServerSession.agentId,
+		).GetBytes())
+		return
+	}
+
+	clanName := utils.ReadStringFromBytes(msg.ClanName[:])
+	player.clanInvite = &clanInvite{
+		requesterId:          msg.RequesterId,
+		requesterCharacterId: msg.RequesterCharacterId,
+		clanId:               msg.ClanId,
+		clanName:             clanName,
+	}
+	_ = player.Send(messages.NewMsgS2CJoinClan(player.PcId, msg.RequesterId, msg.ClanId, clanName).GetBytes())
+}
+
+// handleAnsClan answers a clan invitation. Joining is done by the zone of the
+// invited player, and the result is sent back to whoever invited them.
+func (z *Zone) handleAnsClan(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SAnsClan(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read ans clan",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	invite := player.clanInvite
+	if invite == nil || invite.requesterId != msg.RequesterId || player.clanBusy {
+		return
+	}
+
+	player.clanInvite = nil
+	reply := func(result byte) {
+		_ = player.Send(messages.NewMsgS2CAnsClan(player.PcId, invite.requesterId, result).GetBytes())
+		z.sendToMainServer(player, messages.NewMsgS2MAnsClan(
+			player.PcId,
+			invite.requesterId,
+			result,
+			player.GateServerSession.agentId,
+		).GetBytes())
+	}
+	if msg.Accept == 0 {
+		reply(constants.ClanResultRefused)
+		return
+	}
+
+	if player.SocialInfo.KHId != 0 {
+		reply(constants.ClanResultFailure)
+		return
+	}
+
+	characterId := player.CharacterId
+	z.runClanTask(player, func() error {
+		return z.db.AddClanMember(
+			invite.clanId,
+			invite.requesterCharacterId,
+			characterId,
+			constants.ClanRankMember,
+			constants.ClanMaxMembers,
+		)
+	}, func(err error) {
+		switch {
+		case errors.Is(err, db.ErrClanFull):
+			reply(constants.ClanResultFull)
+			return
+		case err != nil:
+			reply(constants.ClanResultFailure)
+			return
+		}
+
+		applyClanMembership(player, &db.ClanMembership{
+			ClanId:   invite.clanId,
+			ClanName: invite.clanName,
+			Rank:     constants.ClanRankMember,
+		})
+		reply(constants.ClanResultSuccess)
+		z.sendClanInfo(player)
+	})
+}
+
+func (z *Zone) handleMainServerAnsClan(packet []byte) {
+	msg, err := messages.ReadMsgM2SAnsClan(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read main server ans clan",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "mapId", Value: z.mapId},
+		)
+		return
+	}
+
+	player, exists := z.players.Get(msg.PcId)
+	if !exists || player.Zone != z {
+		return
+	}
+
+	_ = player.Send(messages.NewMsgS2CAnsClan(player.PcId, msg.PartnerId, msg.Result).GetBytes())
+}
+
+// handleBoltClan takes a member out of the clan. Members can leave on their
+// own, and officers and the master can put out members ranked below them.
+// The master can only leave a clan with no other members, which disbands it.
+func (z *Zone) handleBoltClan(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SBoltClan(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read bolt clan",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	reply := func(result byte) {
+		_ = player.Send(messages.NewMsgS2CBoltClan(player.PcId, msg.TargetId, result).GetBytes())
+	}
+	leaving := msg.TargetId == player.PcId
+	if player.SocialInfo.KHId == 0 || player.clanBusy ||
+		(!leaving && player.SocialInfo.KHRank < constants.ClanRankOfficer) {
+		reply(constants.ClanResultFailure)
+		return
+	}
+
+	clanId, characterId := player.SocialInfo.KHId, player.CharacterId
+	z.runClanTask(player, func() error {
+		if leaving {
+			return z.db.LeaveClan(clanId, characterId)
+		}
+
+		target, err := z.findClanMember(clanId, msg.TargetId)
+		if err != nil {
+			return err
+		}
+
+		return z.db.RemoveClanMember(clanId, characterId, target.CharacterId)
+	}, func(err error) {
+		if err != nil {
+			reply(constants.ClanResultFailure)
+			return
+		}
+
+		reply(constants.ClanResultSuccess)
+		if leaving {
+			applyClanMembership(player, nil)
+			return
+		}
+
+		z.notifyClanUpdate(player, msg.TargetId)
+	})
+}
+
+func (z *Zone) handleReqClanInfo(player *Player, packet []byte) {
+	if _, err := messages.ReadMsgC2SReqClanInfo(packet); err != nil {
+		z.logger.Error(
+			"Failed to read req clan info",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	z.sendClanInfo(player)
+}
+
+// handleClanUpdate reloads the player's clan after another player changed it,
+// for example by putting the player out or handing the clan over.
+func (z *Zone) handleClanUpdate(packet []byte) {
+	msg, err := messages.ReadMsgM2SClanUpdate(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read clan update",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "mapId", Value: z.mapId},
+		)
+		return
+	}
+
+	player, exists := z.players.Get(msg.PcId)
+	if !exists || player.Zone != z {
+		return
+	}
+
+	characterId := player.CharacterId
+	go func() {
+		membership, err := z.db.GetClanMembership(characterId)
+		if err != nil {
+			return
+		}
+
+		posted := z.Post(func() {
+			if player.GateServerSession == nil {
+				return
+			}
+
+			applyClanMembership(player, membership)
+			z.sendClanInfo(player)
+		})
+		if !posted {
+			z.logger.Error(
+				"Failed to post clan update",
+				shared.Field{Key: "mapId", Value: z.mapId},
+				shared.Field{Key: "pcId", Value: player.PcId},
+			)
+		}
+	}()
+}
+
+// sendClanInfo sends the player the members of the clan. A player without a
+// clan gets an empty list.
+func (z *Zone) sendClanInfo(player *Player) {
+	clanId, clanName := player.SocialInfo.KHId, player.SocialInfo.KHName
+	if clanId == 0 {
+		_ = player.Send(messages.NewMsgS2CClanInfo(player.PcId, 0, "").GetBytes())
+		return
+	}
+
+	go func() {
+		members, err := z.db.GetClanMembers(clanId)
+		if err != nil {
+			return
+		}
+
+		posted := z.Post(func() {
+			info := messages.NewMsgS2CClanInfo(player.PcId, clanId, clanName)
+			for i, member := range members {
+				if i >= len(info.Members) {
+					break
+				}
+
+				info.Members[i] = messages.ClanMember{
+					PcId:  member.AccountId,
+					Level: member.Level,
+					Class: member.Class,
+					Rank:  member.Rank,
+				}
+				copy(info.Members[i].Name[:], utils.MakeFixedLengthStringBytes(member.Name, len(info.Members[i].Name)))
+				info.MemberCount++
+			}
+
+			_ = player.Send(info.GetBytes())
+		})
+		if !posted {
+			z.logger.Error(
+				"Failed to post clan info",
+				shared.Field{Key: "mapId", Value: z.mapId},
+				shared.Field{Key: "pcId", Value: player.PcId},
+			)
+		}
+	}()
+}
+
+// runClanTask runs work off the zone goroutine and done back on it with the
+// result. Only one clan task runs for a player at a time, and done is skipped
+// if the player has logged out in the meantime.
+func (z *Zone) runClanTask(player *Player, work func() error, done func(error)) {
+	player.clanBusy = true
+	go func() {
+		err := work()
+		posted := z.Post(func() {
+			player.clanBusy = false
+			if player.GateServerSession == nil {
+				return
+			}
+
+			done(err)
+		})
+		if !posted {
+			z.logger.Error(
+				"Failed to post clan task result",
+				shared.Field{Key: "mapId", Value: z.mapId},
+				shared.Field{Key: "pcId", Value: player.PcId},
+			)
+		}
+	}()
+}
+
+// notifyClanUpdate asks the main server to have the zone of another clan
+// member reload that member's clan.
+func (z *Zone) notifyClanUpdate(player *Player, targetId uint32) {
+	z.sendToMainServer(player, messages.NewMsgS2MClanUpdate(player.PcId, targetId, player.GateServerSession.agentId).GetBytes())
+}
+
+func (z *Zone) findClanMember(clanId uint32, pcId uint32) (*db.ClanMember, error) {
+	members, err := z.db.GetClanMembers(clanId)
+	if err != nil {
+		return nil, err
+	}
+
+	member := findClanMember(members, pcId)
+	if member == nil {
+		return nil, db.ErrNotClanMember
+	}
+
+	return member, nil
+}
+
+// applyClanMembership sets the clan fields of the player's social info. A
+// nil membership means the player is not in a clan.
+func applyClanMembership(player *Player, membership *db.ClanMembership) {
+	if membership == nil {
+		player.SocialInfo.KHId = 0
+		player.SocialInfo.KHRank = constants.ClanRankNone
+		player.SocialInfo.KHName = ""
+		return
+	}
+
+	player.SocialInfo.KHId = membership.ClanId
+	player.SocialInfo.KHRank = membership.Rank
+	player.SocialInfo.KHName = membership.ClanName
+}
+
+func findClanMember(members []db.ClanMember, pcId uint32) *db.ClanMember {
+	for i := range members {
+		if members[i].AccountId == pcId {
+			return &members[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package zoneserver
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/constants"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages"
+	"github.com/project-agonyl/open-agonyl-servers/internal/zoneserver/pathfinding"
+)
+
+const (
+	normalDamagePercent  = 100
+	meleeAttackRange     = 2
+	mageAttackRange      = 6
+	archerAttackRange    = 8
+	mercenaryAttackRange = 2
+	playerAttackInterval = 800 * time.Millisecond
+)
+
+// defenses groups what an attack is resolved against, so NPC and player
+// targets can share the damage formula.
+type defenses struct {
+	defense      int
+	fireDefense  int
+	iceDefense   int
+	lightDefense int
+}
+
+func (z *Zone) handleAskAttack(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SAskAttack(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read ask attack",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	if player.IsDead() {
+		return
+	}
+
+	now := time.Now()
+	if now.Before(player.NextAttackAt) {
+		return
+	}
+
+	if !z.attack(player, player.PcId, player.Location, playerAttackRange(player.Class), player.Class, player.Stats, msg.TargetId, normalDamagePercent) {
+		return
+	}
+
+	player.NextAttackAt = now.Add(playerAttackInterval)
+}
+
+func (z *Zone) handleHsAskAttack(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SHsAskAttack(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read hs ask attack",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	mercenary := z.getMercenary(player, msg.HsId)
+	if mercenary == nil {
+		return
+	}
+
+	now := time.Now()
+	if now.Before(mercenary.NextAttackAt) {
+		return
+	}
+
+	if !z.attack(player, mercenary.Id, mercenary.Location, mercenaryAttackRange, mercenaryClass, mercenary.Stats, msg.TargetId, normalDamagePercent) {
+		return
+	}
+
+	mercenary.NextAttackAt = now.Add(playerAttackInterval)
+}
+
+// attack resolves a single attack by the player or its mercenary on the
+// target with the given id, dealing damagePercent of the normal damage. It
+// reports whether the attack went through.
+func (z *Zone) attack(
+	player *Player,
+	attackerId uint32,
+	location Location,
+	attackRange int,
+	class byte,
+	stats Stats,
+	targetId uint32,
+	damagePercent int,
+) bool {
+	if npc, exists := z.npcs[targetId]; exists {
+		if npc.IsDead || !npc.IsMonster() || !z.canReach(location, npc.Location, attackRange) {
+			return false
+		}
+
+		damage := attackDamage(class, stats, defenses{
+			defense:      int(npc.Data.Defense) + int(npc.Data.AdditionalDefense),
+			fireDefense:  int(npc.Data.RedAttackDefense),
+			iceDefense:   int(npc.Data.BlueAttackDefense),
+			lightDefense: int(npc.Data.GreyAttackDefense),
+		})
+		z.damageNPC(npc, player, attackerId, scaleDamage(damage, damagePercent))
+		return true
+	}
+
+	target, exists := z.players.Get(targetId)
+	if !exists || target.Zone != z || target.State != PlayerStateInGame || target.IsDead() ||
+		target.PcId == player.PcId || target.SocialInfo.Nation == player.SocialInfo.Nation ||
+		!z.canReach(location, target.Location, attackRange) {
+		return false
+	}
+
+	damage := attackDamage(class, stats, defenses{
+		defense:      int(target.Stats.Defense),
+		fireDefense:  int(target.Stats.FireDefence),
+		iceDefense:   int(target.Stats.IceDefense),
+		lightDefense: int(target.Stats.LightDefense),
+	})
+	z.damagePlayer(target, attackerId, scaleDamage(damage, damagePercent))
+	return true
+}
+
+func (z *Zone) canReach(from Location, to Location, attackRange int) bool {
+	if cellDistance(from.X, from.Y, to.X, to.Y) > attackRange {
+		return false
+	}
+
+	return z.pathfinder.HasLineOfSight(pathfinding.Point{X: from.X, Y: from.Y}, pathfinding.Point{X: to.X, Y: to.Y})
+}
+
+func (z *Zone) damageNPC(npc *NPC, attacker *Player, attackerId uint32, damage uint32) {
+	if damage >= npc.HP {
+		npc.HP = 0
+	} else {
+		npc.HP -= damage
+	}
+
+	attack := messages.NewMsgS2CAttack(attacker.PcId, attackerId, npc.Id, damage, npc.HP).GetBytes()
+	z.BroadcastNearby(npc.Location.X, npc.Location.Y, attack, 0)
+	if npc.HP > 0 {
+		z.provokeMonster(npc, attacker)
+		return
+	}
+
+	die := messages.NewMsgS2CDie(attacker.PcId, npc.Id, attackerId).GetBytes()
+	z.BroadcastNearby(npc.Location.X, npc.Location.Y, die, 0)
+	z.killNPC(npc)
+	z.dropMonsterLoot(npc, attacker)
+	z.awardKillExp(attacker, uint32(npc.Data.PlayerExp))
+}
+
+func (z *Zone) damagePlayer(player *Player, attackerId uint32, damage uint32) {
+	if damage >= uint32(player.Stats.HP) {
+		player.Stats.HP = 0
+	} else {
+		player.Stats.HP -= uint16(damage)
+	}
+
+	player.MarkDirty()
+
+	attack := messages.NewMsgS2CAttack(player.PcId, attackerId, player.PcId, damage, uint32(player.Stats.HP)).GetBytes()
+	z.BroadcastNearby(player.Location.X, player.Location.Y, attack, 0)
+	if player.IsDead() {
+		player.Movement.IsMoving = false
+		die := messages.NewMsgS2CDie(player.PcId, player.PcId, attackerId).GetBytes()
+		z.BroadcastNearby(player.Location.X, player.Location.Y, die, 0)
+	}
+}
+
+// attackDamage resolves the attacker stats against the target defenses.
+// Mages deal magic damage, every other class deals hit damage, and each
+// element adds whatever the target does not resist.
+func attackDamage(class byte, stats Stats, target defenses) uint32 {
+	base := int(stats.HitAttack) + int(stats.AdditionalHitAttack)
+	if class == constants.ClassMage {
+		base = int(stats.MagicAttack) + int(stats.AdditionalMagicAttack)
+	}
+
+	damage := max(base-target.defense, 0)
+	damage += max(int(stats.FireAttack)-target.fireDefense, 0)
+	damage += max(int(stats.IceAttack)-target.iceDefense, 0)
+	damage += max(int(stats.LightAttack)-target.lightDefense, 0)
+	damage = damage * (90 + rand.IntN(21)) / 100
+	return uint32(max(damage, 1))
+}
+
+func scaleDamage(damage uint32, damagePercent int) uint32 {
+	return uint32(max(int(damage)*damagePercent/100, 1))
+}
+
+func playerAttackRange(class byte) int {
+	switch class {
+	case constants.ClassMage:
+		return mageAttackRange
+	case constants.ClassArcher:
+		return archerAttackRange
+	default:
+		return meleeAttackRange
+	}
+}
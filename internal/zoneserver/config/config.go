@@ -11,22 +11,32 @@ import (
 )
 
 type EnvVars struct {
-	Port                string
-	IpAddress           string
-	Environment         string
-	LogLevel            string
-	DatabaseURL         string
-	CacheServerAddr     string
-	CacheServerPassword string
-	CacheTlsEnabled     bool
-	CacheKeyPrefix      string
-	ZoneDataItemPath    string
-	ZoneDataNPCPath     string
-	ZoneDataMapPath     string
-	MainServerIpAddress string
-	MainServerPort      string
-	ServerId            byte
-	MapIDs              []uint16
+	Port                   string
+	IpAddress              string
+	Environment            string
+	LogLevel               string
+	DatabaseURL            string
+	CacheServerAddr        string
+	CacheServerPassword    string
+	CacheTlsEnabled        bool
+	CacheKeyPrefix         string
+	ZoneDataItemPath       string
+	ZoneDataNPCPath        string
+	ZoneDataMapPath        string
+	ZoneDataSpawnPath      string
+	ZoneDataDropPath       string
+	ZoneDataShopPath       string
+	ZoneDataConsumablePath string
+	ZoneDataSkillPath      string
+	ZoneDataClassStatPath  string
+	ZoneDataLevelPath      string
+	ZoneDataPartyExpPath   string
+	MainServerIpAddress    string
+	MainServerPort         string
+	ServerId               byte
+	MapIDs                 []uint16
+	ZoneTickRate           int
+	ZoneSaveInterval       int
 }
 
 func New() *EnvVars {
@@ -119,6 +129,62 @@ func New() *EnvVars {
 		}
 	}
 
+	if _, ok := os.LookupEnv("ZONE_DATA_SPAWN_PATH"); !ok {
+		err := os.Setenv("ZONE_DATA_SPAWN_PATH", "ZoneData/map")
+		if err != nil {
+			slog.Info("Could not set default ZONE_DATA_SPAWN_PATH!")
+		}
+	}
+
+	if _, ok := os.LookupEnv("ZONE_DATA_DROP_PATH"); !ok {
+		err := os.Setenv("ZONE_DATA_DROP_PATH", "ZoneData/drop.json")
+		if err != nil {
+			slog.Info("Could not set default ZONE_DATA_DROP_PATH!")
+		}
+	}
+
+	if _, ok := os.LookupEnv("ZONE_DATA_SHOP_PATH"); !ok {
+		err := os.Setenv("ZONE_DATA_SHOP_PATH", "ZoneData/Shop")
+		if err != nil {
+			slog.Info("Could not set default ZONE_DATA_SHOP_PATH!")
+		}
+	}
+
+	if _, ok := os.LookupEnv("ZONE_DATA_CONSUMABLE_PATH"); !ok {
+		err := os.Setenv("ZONE_DATA_CONSUMABLE_PATH", "ZoneData/consumable.json")
+		if err != nil {
+			slog.Info("Could not set default ZONE_DATA_CONSUMABLE_PATH!")
+		}
+	}
+
+	if _, ok := os.LookupEnv("ZONE_DATA_SKILL_PATH"); !ok {
+		err := os.Setenv("ZONE_DATA_SKILL_PATH", "ZoneData/skill.json")
+		if err != nil {
+			slog.Info("Could not set default ZONE_DATA_SKILL_PATH!")
+		}
+	}
+
+	if _, ok := os.LookupEnv("ZONE_DATA_CLASS_STAT_PATH"); !ok {
+		err := os.Setenv("ZONE_DATA_CLASS_STAT_PATH", "ZoneData/class_stat.json")
+		if err != nil {
+			slog.Info("Could not set default ZONE_DATA_CLASS_STAT_PATH!")
+		}
+	}
+
+	if _, ok := os.LookupEnv("ZONE_DATA_LEVEL_PATH"); !ok {
+		err := os.Setenv("ZONE_DATA_LEVEL_PATH", "ZoneData/level.json")
+		if err != nil {
+			slog.Info("Could not set default ZONE_DATA_LEVEL_PATH!")
+		}
+	}
+
+	if _, ok := os.LookupEnv("ZONE_DATA_PARTY_EXP_PATH"); !ok {
+		err := os.Setenv("ZONE_DATA_PARTY_EXP_PATH", "ZoneData/party_exp.json")
+		if err != nil {
+			slog.Info("Could not set default ZONE_DATA_PARTY_EXP_PATH!")
+		}
+	}
+
 	if _, ok := os.LookupEnv("MAIN_SERVER_IP_ADDRESS"); !ok {
 		err := os.Setenv("MAIN_SERVER_IP_ADDRESS", "127.0.0.1")
 		if err != nil {
@@ -163,23 +229,57 @@ func New() *EnvVars {
 		mapIdsUint = append(mapIdsUint, uint16(mapIdUint))
 	}
 
+	if _, ok := os.LookupEnv("ZONE_TICK_RATE"); !ok {
+		err := os.Setenv("ZONE_TICK_RATE", "10")
+		if err != nil {
+			slog.Info("Could not set default ZONE_TICK_RATE!")
+		}
+	}
+
+	zoneTickRate, err := strconv.Atoi(os.Getenv("ZONE_TICK_RATE"))
+	if err != nil || zoneTickRate <= 0 {
+		zoneTickRate = 10
+	}
+
+	if _, ok := os.LookupEnv("ZONE_SAVE_INTERVAL"); !ok {
+		err := os.Setenv("ZONE_SAVE_INTERVAL", "60")
+		if err != nil {
+			slog.Info("Could not set default ZONE_SAVE_INTERVAL!")
+		}
+	}
+
+	zoneSaveInterval, err := strconv.Atoi(os.Getenv("ZONE_SAVE_INTERVAL"))
+	if err != nil || zoneSaveInterval <= 0 {
+		zoneSaveInterval = 60
+	}
+
 	return &EnvVars{
-		Port:                os.Getenv("PORT"),
-		IpAddress:           os.Getenv("IP_ADDRESS"),
-		Environment:         os.Getenv("ENVIRONMENT"),
-		LogLevel:            os.Getenv("LOG_LEVEL"),
-		DatabaseURL:         os.Getenv("DATABASE_URL"),
-		CacheServerAddr:     os.Getenv("CACHE_SERVER_ADDR"),
-		CacheServerPassword: os.Getenv("CACHE_SERVER_PASSWORD"),
-		CacheTlsEnabled:     cacheTlsEnabled,
-		CacheKeyPrefix:      os.Getenv("CACHE_KEY_PREFIX"),
-		ZoneDataItemPath:    os.Getenv("ZONE_DATA_ITEM_PATH"),
-		ZoneDataNPCPath:     os.Getenv("ZONE_DATA_NPC_PATH"),
-		ZoneDataMapPath:     os.Getenv("ZONE_DATA_MAP_PATH"),
-		MainServerIpAddress: os.Getenv("MAIN_SERVER_IP_ADDRESS"),
-		MainServerPort:      os.Getenv("MAIN_SERVER_PORT"),
-		ServerId:            byte(serverId),
-		MapIDs:              mapIdsUint,
+		Port:                   os.Getenv("PORT"),
+		IpAddress:              os.Getenv("IP_ADDRESS"),
+		Environment:            os.Getenv("ENVIRONMENT"),
+		LogLevel:               os.Getenv("LOG_LEVEL"),
+		DatabaseURL:            os.Getenv("DATABASE_URL"),
+		CacheServerAddr:        os.Getenv("CACHE_SERVER_ADDR"),
+		CacheServerPassword:    os.Getenv("CACHE_SERVER_PASSWORD"),
+		CacheTlsEnabled:        cacheTlsEnabled,
+		CacheKeyPrefix:         os.Getenv("CACHE_KEY_PREFIX"),
+		ZoneDataItemPath:       os.Getenv("ZONE_DATA_ITEM_PATH"),
+		ZoneDataNPCPath:        os.Getenv("ZONE_DATA_NPC_PATH"),
+		ZoneDataMapPath:        os.Getenv("ZONE_DATA_MAP_PATH"),
+		ZoneDataSpawnPath:      os.Getenv("ZONE_DATA_SPAWN_PATH"),
+		ZoneDataDropPath:       os.Getenv("ZONE_DATA_DROP_PATH"),
+		ZoneDataShopPath:       os.Getenv("ZONE_DATA_SHOP_PATH"),
+		ZoneDataConsumablePath: os.Getenv("ZONE_DATA_CONSUMABLE_PATH"),
+		ZoneDataSkillPath:      os.Getenv("ZONE_DATA_SKILL_PATH"),
+		ZoneDataClassStatPath:  os.Getenv("ZONE_DATA_CLASS_STAT_PATH"),
+		ZoneDataLevelPath:      os.Getenv("ZONE_DATA_LEVEL_PATH"),
+		ZoneDataPartyExpPath:   os.Getenv("ZONE_DATA_PARTY_EXP_PATH"),
+		MainServerIpAddress:    os.Getenv("MAIN_SERVER_IP_ADDRESS"),
+		MainServerPort:         os.Getenv("MAIN_SERVER_PORT"),
+		ServerId:               byte(serverId),
+		MapIDs:                 mapIdsUint,
+		ZoneTickRate:           zoneTickRate,
+		ZoneSaveInterval:       zoneSaveInterval,
 	}
 }
 
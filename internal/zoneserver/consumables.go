@@ -0,0 +1,146 @@
+package zoneserver
+
+import (
+	"slices"
+	"time"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/data"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages"
+)
+
+// stackCountMask picks the number of consumables in a stack out of its item
+// option.
+const stackCountMask uint32 = 0xFF
+
+func (z *Zone) handleUsePotion(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SUsePotion(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read use potion",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	z.usePotion(player, findInventorySlot(player.Inventory, msg.Slot), msg.Slot)
+}
+
+// handleUsePotionEx drinks a potion by item code rather than slot, taking it
+// from the first stack of it in the inventory.
+func (z *Zone) handleUsePotionEx(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SUsePotionEx(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read use potion ex",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	index := slices.IndexFunc(player.Inventory, func(item InventoryItem) bool {
+		return item.ItemCode == msg.ItemCode
+	})
+	var slot byte
+	if index >= 0 {
+		slot = player.Inventory[index].Slot
+	}
+
+	z.usePotion(player, index, slot)
+}
+
+func (z *Zone) usePotion(player *Player, index int, slot byte) {
+	fail := func() {
+		_ = player.Send(messages.NewMsgS2CUsePotion(player.PcId, slot, 0, player.Stats.HP, player.Stats.MP, itemResultFailure).GetBytes())
+	}
+	if index < 0 {
+		fail()
+		return
+	}
+
+	consumable, ok := z.getUsableConsumable(player, player.Inventory[index].ItemCode)
+	if !ok || !consumable.IsPotion() {
+		fail()
+		return
+	}
+
+	itemOption := z.useConsumable(player, index, consumable)
+	_ = player.Send(messages.NewMsgS2CUsePotion(player.PcId, slot, itemOption, player.Stats.HP, player.Stats.MP, itemResultSuccess).GetBytes())
+	_ = player.Send(newPcStatsMsg(player).GetBytes())
+}
+
+func (z *Zone) handleUseItem(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SUseItem(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read use item",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	index := findInventorySlot(player.Inventory, msg.Slot)
+	if index < 0 {
+		_ = player.Send(messages.NewMsgS2CUseItem(player.PcId, msg.Slot, 0, itemResultFailure).GetBytes())
+		return
+	}
+
+	consumable, ok := z.getUsableConsumable(player, player.Inventory[index].ItemCode)
+	if !ok {
+		_ = player.Send(messages.NewMsgS2CUseItem(player.PcId, msg.Slot, 0, itemResultFailure).GetBytes())
+		return
+	}
+
+	itemOption := z.useConsumable(player, index, consumable)
+	_ = player.Send(messages.NewMsgS2CUseItem(player.PcId, msg.Slot, itemOption, itemResultSuccess).GetBytes())
+	if consumable.IsPotion() {
+		_ = player.Send(newPcStatsMsg(player).GetBytes())
+	}
+}
+
+// getUsableConsumable returns the consumable for the item code if the player
+// can use it now. The cooldown is checked against the server's clock, so a
+// client running fast cannot use items any sooner.
+func (z *Zone) getUsableConsumable(player *Player, itemCode uint32) (data.Consumable, bool) {
+	consumable, exists := z.zoneManager.GetConsumable(itemCode)
+	if !exists || player.IsDead() {
+		return consumable, false
+	}
+
+	if readyAt, exists := player.cooldowns[consumable.CooldownGroup]; exists && time.Now().Before(readyAt) {
+		return consumable, false
+	}
+
+	return consumable, true
+}
+
+// useConsumable applies the consumable, starts the cooldown of its group and
+// takes one from the stack in the inventory. It returns the item option left
+// on the stack, which is 0 if the stack was used up.
+func (z *Zone) useConsumable(player *Player, index int, consumable data.Consumable) uint32 {
+	if consumable.CooldownMs > 0 {
+		if player.cooldowns == nil {
+			player.cooldowns = make(map[byte]time.Time)
+		}
+
+		player.cooldowns[consumable.CooldownGroup] = time.Now().Add(time.Duration(consumable.CooldownMs) * time.Millisecond)
+	}
+
+	player.Stats.HP = uint16(min(int(player.Stats.HP)+int(consumable.HP), int(player.Stats.MaxHp)))
+	player.Stats.MP = uint16(min(int(player.Stats.MP)+int(consumable.MP), int(player.Stats.MaxMp)))
+	itemOption := uint32(0)
+	item := &player.Inventory[index]
+	if count := item.ItemOption & stackCountMask; count > 1 {
+		item.ItemOption = item.ItemOption&^stackCountMask | (count - 1)
+		itemOption = item.ItemOption
+	} else {
+		player.Inventory = slices.Delete(player.Inventory, index, index+1)
+	}
+
+	player.MarkDirty()
+	z.awardExp(player, consumable.Exp)
+	return itemOption
+}
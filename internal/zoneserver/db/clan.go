@@ -0,0 +1,552 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/constants"
+)
+
+var (
+	ErrClanNotFound      = errors.New("clan does not exist")
+	ErrClanNameTaken     = errors.New("clan name is already taken")
+	ErrClanFull          = errors.New("clan has no room for more members")
+	ErrAlreadyInClan     = errors.New("character is already in a clan")
+	ErrNotClanMember     = errors.New("character is not a member of the clan")
+	ErrClanRankTooLow    = errors.New("clan rank too low for the change")
+	ErrClanMasterLeaving = errors.New("clan master cannot leave a clan with members")
+)
+
+// ClanMembership is the clan a character is in and the character's rank in
+// it.
+type ClanMembership struct {
+	ClanId   uint32 `db:"clan_id"`
+	ClanName string `db:"clan_name"`
+	Rank     byte   `db:"rank"`
+}
+
+type ClanMember struct {
+	CharacterId uint32 `db:"character_id"`
+	AccountId   uint32 `db:"account_id"`
+	Name        string `db:"name"`
+	Level       uint16 `db:"level"`
+	Class       byte   `db:"class"`
+	Rank        byte   `db:"rank"`
+}
+
+// GetClanMembership returns the clan the character is in, or nil if the
+// character is not in one.
+func (s *dbService) GetClanMembership(characterId uint32) (*ClanMembership, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query, args, err := psql.Select(
+		"clan_members.clan_id",
+		"clans.name as clan_name",
+		"clan_members.rank",
+	).
+		From("clan_members").
+		Join("clans ON clans.id = clan_members.clan_id").
+		Where(sq.Eq{"clan_members.character_id": characterId}).
+		ToSql()
+	if err != nil {
+		s.logger.Error("Failed to build get clan membership query", shared.Field{Key: "error", Value: err})
+		return nil, err
+	}
+
+	membership := &ClanMembership{}
+	err = s.db.Get(membership, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		s.logger.Error("Failed to execute get clan membership query", shared.Field{Key: "error", Value: err})
+		return nil, err
+	}
+
+	return membership, nil
+}
+
+// GetClanMembers returns the members of the clan, highest rank first.
+func (s *dbService) GetClanMembers(clanId uint32) ([]ClanMember, error) {
+	return s.getClanMembers(s.db, clanId)
+}
+
+func (s *dbService) getClanMembers(q sqlx.Queryer, clanId uint32) ([]ClanMember, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query, args, err := psql.Select(
+		"clan_members.character_id",
+		"characters.account_id",
+		"characters.name",
+		"characters.level",
+		"characters.class",
+		"clan_members.rank",
+	).
+		From("clan_members").
+		Join("characters ON characters.id = clan_members.character_id").
+		Where(sq.Eq{"clan_members.clan_id": clanId}).
+		OrderBy("clan_members.rank DESC", "clan_members.joined_at").
+		ToSql()
+	if err != nil {
+		s.logger.Error("Failed to build get clan members query", shared.Field{Key: "error", Value: err})
+		return nil, err
+	}
+
+	members := make([]ClanMember, 0)
+	if err := sqlx.Select(q, &members, query, args...); err != nil {
+		s.logger.Error("Failed to execute get clan members query", shared.Field{Key: "error", Value: err})
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// CreateClan creates a clan with the character as its only member and
+// returns the id of the clan.
+func (s *dbService) CreateClan(characterId uint32, name string, rank byte) (uint32, error) {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		s.logger.Error("Failed to begin create clan transaction", shared.Field{Key: "error", Value: err})
+		return 0, err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query, args, err := psql.Insert("clans").
+		Columns("name").
+		Values(name).
+		Suffix("ON CONFLICT (name) DO NOTHING RETURNING id").
+		ToSql()
+	if err != nil {
+		s.logger.Error("Failed to build create clan query", shared.Field{Key: "error", Value: err})
+		return 0, err
+	}
+
+	var clanId uint32
+	err = tx.Get(&clanId, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrClanNameTaken
+	}
+
+	if err != nil {
+		s.logger.Error("Failed to execute create clan query", shared.Field{Key: "error", Value: err})
+		return 0, err
+	}
+
+	query, args, err = psql.Insert("clan_members").
+		Columns("character_id", "clan_id", "rank").
+		Values(characterId, clanId, rank).
+		Suffix("ON CONFLICT (character_id) DO NOTHING").
+		ToSql()
+	if err != nil {
+		s.logger.Error("Failed to build add clan master query", shared.Field{Key: "error", Value: err})
+		return 0, err
+	}
+
+	result, err := tx.Exec(query, args...)
+	if err != nil {
+		s.logger.Error("Failed to execute add clan master query", shared.Field{Key: "error", Value: err})
+		return 0, err
+	}
+
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return 0, ErrAlreadyInClan
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("Failed to commit create clan transaction", shared.Field{Key: "error", Value: err})
+		return 0, err
+	}
+
+	return clanId, nil
+}
+
+// AddClanMember puts the character in the clan with the given rank, on the
+// invitation of inviterId. The inviter has to be an officer or the master of
+// the clan when the character joins, otherwise ErrClanRankTooLow is returned.
+// The clan is locked while the members are counted, so concurrent joins
+// cannot take the clan past maxMembers.
+func (s *dbService) AddClanMember(clanId uint32, inviterId uint32, characterId uint32, rank byte, maxMembers int) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		s.logger.Error("Failed to begin add clan member transaction", shared.Field{Key: "error", Value: err})
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err := s.lockClan(tx, clanId); err != nil {
+		return err
+	}
+
+	inviterRank, err := s.getClanRank(tx, clanId, inviterId)
+	if err != nil {
+		return err
+	}
+
+	if inviterRank < constants.ClanRankOfficer {
+		return ErrClanRankTooLow
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query, args, err := psql.Select("COUNT(*)").
+		From("clan_members").
+		Where(sq.Eq{"clan_id": clanId}).
+		ToSql()
+	if err != nil {
+		s.logger.Error("Failed to build count clan members query", shared.Field{Key: "error", Value: err})
+		return err
+	}
+
+	var count int
+	if err := tx.Get(&count, query, args...); err != nil {
+		s.logger.Error("Failed to execute count clan members query", shared.Field{Key: "error", Value: err})
+		return err
+	}
+
+	if count >= maxMembers {
+		return ErrClanFull
+	}
+
+	query, args, err = psql.Insert("clan_members").
+		Columns("character_id", "clan_id", "rank").
+		Values(characterId, clanId, rank).
+		Suffix("ON CONFLICT (character_id) DO NOTHING").
+		ToSql()
+	if err != nil {
+		s.logger.Error("Failed to build add clan member query", shared.Field{Key: "error", Value: err})
+		return err
+	}
+
+	result, err := tx.Exec(query, args...)
+	if err != nil {
+		s.logger.Error("Failed to execute add clan member query", shared.Field{Key: "error", Value: err})
+		return err
+	}
+
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return ErrAlreadyInClan
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("Failed to commit add clan member transaction", shared.Field{Key: "error", Value: err})
+		return err
+	}
+
+	return nil
+}
+
+// RemoveClanMember puts the character out of the clan on behalf of actorId,
+// who has to be an officer or the master and rank above the character.
+// ErrClanRankTooLow is returned otherwise, and ErrNotClanMember if either of
+// them is not in the clan.
+func (s *dbService) RemoveClanMember(clanId uint32, actorId uint32, characterId uint32) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		s.logger.Error("Failed to begin remove clan member transaction", shared.Field{Key: "error", Value: err})
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err := s.lockClan(tx, clanId); err != nil {
+		return err
+	}
+
+	actorRank, err := s.getClanRank(tx, clanId, actorId)
+	if err != nil {
+		return err
+	}
+
+	rank, err := s.getClanRank(tx, clanId, characterId)
+	if err != nil {
+		return err
+	}
+
+	if actorRank < constants.ClanRankOfficer || rank >= actorRank {
+		return ErrClanRankTooLow
+	}
+
+	if err := s.deleteClanMember(tx, clanId, characterId); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("Failed to commit remove clan member transaction", shared.Field{Key: "error", Value: err})
+		return err
+	}
+
+	return nil
+}
+
+// LeaveClan takes the character out of the clan. The master can only leave
+// a clan with no other members, which disbands it; ErrClanMasterLeaving is
+// returned otherwise.
+func (s *dbService) LeaveClan(clanId uint32, characterId uint32) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		s.logger.Error("Failed to begin leave clan transaction", shared.Field{Key: "error", Value: err})
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err := s.lockClan(tx, clanId); err != nil {
+		return err
+	}
+
+	rank, err := s.getClanRank(tx, clanId, characterId)
+	if err != nil {
+		return err
+	}
+
+	if rank == constants.ClanRankMaster {
+		psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+		query, args, err := psql.Select("COUNT(*)").
+			From("clan_members").
+			Where(sq.Eq{"clan_id": clanId}).
+			ToSql()
+		if err != nil {
+			s.logger.Error("Failed to build count clan members query", shared.Field{Key: "error", Value: err})
+			return err
+		}
+
+		var count int
+		if err := tx.Get(&count, query, args...); err != nil {
+			s.logger.Error("Failed to execute count clan members query", shared.Field{Key: "error", Value: err})
+			return err
+		}
+
+		if count > 1 {
+			return ErrClanMasterLeaving
+		}
+
+		err = s.deleteClan(tx, clanId)
+	} else {
+		err = s.deleteClanMember(tx, clanId, characterId)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("Failed to commit leave clan transaction", shared.Field{Key: "error", Value: err})
+		return err
+	}
+
+	return nil
+}
+
+// SetClanRanks changes the rank of clan members in one transaction, so that
+// handing the clan over to another member never leaves it with two masters
+// or none. masterId has to be the master of the clan, otherwise
+// ErrClanRankTooLow is returned, and every character has to be in the clan,
+// otherwise ErrNotClanMember is returned. In both cases no rank is changed.
+func (s *dbService) SetClanRanks(clanId uint32, masterId uint32, ranks map[uint32]byte) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		s.logger.Error("Failed to begin set clan ranks transaction", shared.Field{Key: "error", Value: err})
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err := s.lockClan(tx, clanId); err != nil {
+		return err
+	}
+
+	masterRank, err := s.getClanRank(tx, clanId, masterId)
+	if err != nil {
+		return err
+	}
+
+	if masterRank != constants.ClanRankMaster {
+		return ErrClanRankTooLow
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	for characterId, rank := range ranks {
+		query, args, err := psql.Update("clan_members").
+			Set("rank", rank).
+			Set("updated_at", sq.Expr("NOW()")).
+			Where(sq.And{sq.Eq{"clan_id": clanId}, sq.Eq{"character_id": characterId}}).
+			ToSql()
+		if err != nil {
+			s.logger.Error("Failed to build set clan rank query", shared.Field{Key: "error", Value: err})
+			return err
+		}
+
+		result, err := tx.Exec(query, args...)
+		if err != nil {
+			s.logger.Error("Failed to execute set clan rank query", shared.Field{Key: "error", Value: err})
+			return err
+		}
+
+		if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+			return ErrNotClanMember
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("Failed to commit set clan ranks transaction", shared.Field{Key: "error", Value: err})
+		return err
+	}
+
+	return nil
+}
+
+// DisbandClan deletes the clan along with its memberships and returns the
+// members it had. masterId has to be the master of the clan, otherwise
+// ErrClanRankTooLow is returned. The members are read under the clan lock,
+// so nobody can join between them being read and the clan being deleted.
+func (s *dbService) DisbandClan(clanId uint32, masterId uint32) ([]ClanMember, error) {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		s.logger.Error("Failed to begin disband clan transaction", shared.Field{Key: "error", Value: err})
+		return nil, err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err := s.lockClan(tx, clanId); err != nil {
+		return nil, err
+	}
+
+	masterRank, err := s.getClanRank(tx, clanId, masterId)
+	if err != nil {
+		return nil, err
+	}
+
+	if masterRank != constants.ClanRankMaster {
+		return nil, ErrClanRankTooLow
+	}
+
+	members, err := s.getClanMembers(tx, clanId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.deleteClan(tx, clanId); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("Failed to commit disband clan transaction", shared.Field{Key: "error", Value: err})
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// lockClan locks the clan row until the transaction ends. Every change to
+// the members of a clan takes this lock first, so ranks checked after it
+// cannot change before the transaction commits.
+func (s *dbService) lockClan(tx *sqlx.Tx, clanId uint32) error {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query, args, err := psql.Select("id").
+		From("clans").
+		Where(sq.Eq{"id": clanId}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		s.logger.Error("Failed to build lock clan query", shared.Field{Key: "error", Value: err})
+		return err
+	}
+
+	var lockedId uint32
+	err = tx.Get(&lockedId, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrClanNotFound
+	}
+
+	if err != nil {
+		s.logger.Error("Failed to execute lock clan query", shared.Field{Key: "error", Value: err})
+		return err
+	}
+
+	return nil
+}
+
+// getClanRank returns the rank of the character in the clan, or
+// ErrNotClanMember if the character is not in it.
+func (s *dbService) getClanRank(tx *sqlx.Tx, clanId uint32, characterId uint32) (byte, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query, args, err := psql.Select("rank").
+		From("clan_members").
+		Where(sq.And{sq.Eq{"clan_id": clanId}, sq.Eq{"character_id": characterId}}).
+		ToSql()
+	if err != nil {
+		s.logger.Error("Failed to build get clan rank query", shared.Field{Key: "error", Value: err})
+		return 0, err
+	}
+
+	var rank byte
+	err = tx.Get(&rank, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrNotClanMember
+	}
+
+	if err != nil {
+		s.logger.Error("Failed to execute get clan rank query", shared.Field{Key: "error", Value: err})
+		return 0, err
+	}
+
+	return rank, nil
+}
+
+func (s *dbService) deleteClanMember(tx *sqlx.Tx, clanId uint32, characterId uint32) error {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query, args, err := psql.Delete("clan_members").
+		Where(sq.And{sq.Eq{"clan_id": clanId}, sq.Eq{"character_id": characterId}}).
+		ToSql()
+	if err != nil {
+		s.logger.Error("Failed to build remove clan member query", shared.Field{Key: "error", Value: err})
+		return err
+	}
+
+	result, err := tx.Exec(query, args...)
+	if err != nil {
+		s.logger.Error("Failed to execute remove clan member query", shared.Field{Key: "error", Value: err})
+		return err
+	}
+
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return ErrNotClanMember
+	}
+
+	return nil
+}
+
+func (s *dbService) deleteClan(tx *sqlx.Tx, clanId uint32) error {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query, args, err := psql.Delete("clans").
+		Where(sq.Eq{"id": clanId}).
+		ToSql()
+	if err != nil {
+		s.logger.Error("Failed to build disband clan query", shared.Field{Key: "error", Value: err})
+		return err
+	}
+
+	if _, err := tx.Exec(query, args...); err != nil {
+		s.logger.Error("Failed to execute disband clan query", shared.Field{Key: "error", Value: err})
+		return err
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
@@ -11,8 +12,23 @@ import (
 	"github.com/project-agonyl/open-agonyl-servers/internal/shared/constants"
 )
 
+var ErrCharacterVersionConflict = errors.New("character was saved by another writer")
+
 type DBService interface {
 	GetCharacter(id uint32, name string) (*Character, error)
+	SaveCharacter(characterId uint32, version uint64, level uint16, exp uint32, data *CharacterData) (uint64, error)
+	SaveCharacters(saves []CharacterSave) ([]uint64, error)
+	OpenStorage(accountId uint32, characterId uint32) (*Storage, error)
+	CloseStorage(accountId uint32, characterId uint32) error
+	SaveCharacterAndStorage(character CharacterSave, storage StorageSave) (uint64, uint64, error)
+	GetClanMembership(characterId uint32) (*ClanMembership, error)
+	GetClanMembers(clanId uint32) ([]ClanMember, error)
+	CreateClan(characterId uint32, name string, rank byte) (uint32, error)
+	AddClanMember(clanId uint32, inviterId uint32, characterId uint32, rank byte, maxMembers int) error
+	RemoveClanMember(clanId uint32, actorId uint32, characterId uint32) error
+	LeaveClan(clanId uint32, characterId uint32) error
+	SetClanRanks(clanId uint32, masterId uint32, ranks map[uint32]byte) error
+	DisbandClan(clanId uint32, masterId uint32) ([]ClanMember, error)
 	GetDB() *sqlx.DB
 	Close() error
 }
@@ -49,6 +65,8 @@ func (s *dbService) GetCharacter(id uint32, name string) (*Character, error) {
 		"characters.name",
 		"characters.class",
 		"characters.level",
+		"characters.experience_points",
+		"characters.version",
 		"characters.character_data",
 		"accounts.username as account",
 	).
@@ -78,28 +96,118 @@ func (s *dbService) GetCharacter(id uint32, name string) (*Character, error) {
 	return character, nil
 }
 
+// SaveCharacter writes the character back if it is still at the given
+// version and returns the new version. ErrCharacterVersionConflict is returned
+// when another writer saved the character first.
+func (s *dbService) SaveCharacter(characterId uint32, version uint64, level uint16, exp uint32, data *CharacterData) (uint64, error) {
+	return s.saveCharacter(s.db, CharacterSave{
+		CharacterId: characterId,
+		Version:     version,
+		Level:       level,
+		Exp:         exp,
+		Data:        data,
+	})
+}
+
+// SaveCharacters saves all the characters in one transaction, so that either
+// every save goes through or none does.
+func (s *dbService) SaveCharacters(saves []CharacterSave) ([]uint64, error) {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		s.logger.Error("Failed to begin save characters transaction", shared.Field{Key: "error", Value: err})
+		return nil, err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	versions := make([]uint64, len(saves))
+	for i, save := range saves {
+		version, err := s.saveCharacter(tx, save)
+		if err != nil {
+			return nil, err
+		}
+
+		versions[i] = version
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("Failed to commit save characters transaction", shared.Field{Key: "error", Value: err})
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (s *dbService) saveCharacter(q sqlx.Queryer, save CharacterSave) (uint64, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	qb := psql.Update("characters").
+		Set("level", save.Level).
+		Set("experience_points", save.Exp).
+		Set("woonz", save.Data.Parole).
+		Set("character_data", save.Data).
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.And{sq.Eq{"id": save.CharacterId}, sq.Eq{"version": save.Version}}).
+		Suffix("RETURNING version")
+
+	query, args, err := qb.ToSql()
+	if err != nil {
+		s.logger.Error("Failed to build save character query", shared.Field{Key: "error", Value: err})
+		return 0, err
+	}
+
+	var newVersion uint64
+	err = sqlx.Get(q, &newVersion, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrCharacterVersionConflict
+	}
+
+	if err != nil {
+		s.logger.Error("Failed to execute save character query", shared.Field{Key: "error", Value: err})
+		return 0, err
+	}
+
+	return newVersion, nil
+}
+
+type CharacterSave struct {
+	CharacterId uint32
+	Version     uint64
+	Level       uint16
+	Exp         uint32
+	Data        *CharacterData
+}
+
 type Character struct {
 	ID      uint32        `db:"id"`
 	Name    string        `db:"name"`
 	Class   byte          `db:"class"`
 	Level   uint16        `db:"level"`
+	Exp     uint32        `db:"experience_points"`
+	Version uint64        `db:"version"`
 	Account string        `db:"account"`
 	Data    CharacterData `db:"character_data"`
 }
 
 type CharacterData struct {
-	Parole       uint32          `json:"parole"`
-	SocialInfo   SocialInfo      `json:"social_info"`
-	Wear         []WearItem      `json:"wear"`
-	Inventory    []InventoryItem `json:"inventory"`
-	Lore         uint32          `json:"lore"`
-	Location     Location        `json:"location"`
-	CurrentQuest QuestInfo       `json:"current_quest"`
-	Skills       []SkillInfo     `json:"skills"`
-	Stats        Stats           `json:"stats"`
-	NPCFavors    []NPCFavor      `json:"npc_favors"`
-	ActivePet    Pet             `json:"active_pet"`
-	PetInventory []PetInventory  `json:"pet_inventory"`
+	Parole           uint32          `json:"parole"`
+	SocialInfo       SocialInfo      `json:"social_info"`
+	Wear             []WearItem      `json:"wear"`
+	Inventory        []InventoryItem `json:"inventory"`
+	Lore             uint32          `json:"lore"`
+	Location         Location        `json:"location"`
+	CurrentQuest     QuestInfo       `json:"current_quest"`
+	Skills           []SkillInfo     `json:"skills"`
+	Stats            Stats           `json:"stats"`
+	NPCFavors        []NPCFavor      `json:"npc_favors"`
+	ActivePet        Pet             `json:"active_pet"`
+	PetInventory     []PetInventory  `json:"pet_inventory"`
+	ChatWindowOption uint32          `json:"chat_window_option"`
+	PassiveSkills    []SkillInfo     `json:"passive_skills"`
+	SkillSlots       [0x14]byte      `json:"skill_slots"`
+	Mercenary        *Mercenary      `json:"mercenary,omitempty"`
 }
 
 func (c *CharacterData) Scan(value interface{}) error {
@@ -168,6 +276,13 @@ type Stats struct {
 	MPCapacity      uint16 `json:"mp_capacity"`
 }
 
+// Mercenary is the mercenary a character has hired. Characters without one
+// have no mercenary in their data.
+type Mercenary struct {
+	Stats Stats      `json:"stats"`
+	Wear  []WearItem `json:"wear"`
+}
+
 type NPCFavor struct {
 	NPCID uint32 `json:"npc_id"`
 	Favor uint16 `json:"favor"`
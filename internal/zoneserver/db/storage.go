@@ -0,0 +1,187 @@
+package db
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+)
+
+// storageLockTimeout is how long a storage lock is honoured without being
+// used, so that a lock left behind by a zone server that went down does not
+// keep the storage shut forever.
+const storageLockTimeout = 10 * time.Minute
+
+var (
+	ErrStorageLocked          = errors.New("storage is open by another character")
+	ErrStorageVersionConflict = errors.New("storage was saved by another writer")
+)
+
+type Storage struct {
+	Items   StorageItems `db:"items"`
+	Woonz   uint32       `db:"woonz"`
+	Version uint64       `db:"version"`
+}
+
+type StorageSave struct {
+	AccountId   uint32
+	CharacterId uint32
+	Version     uint64
+	Items       StorageItems
+	Woonz       uint32
+}
+
+type StorageItems []InventoryItem
+
+func (s *StorageItems) Scan(value interface{}) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("StorageItems: type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, s)
+}
+
+func (s StorageItems) Value() (driver.Value, error) {
+	if s == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(s)
+}
+
+// OpenStorage locks the storage of the account for the character and returns
+// its contents. ErrStorageLocked is returned when another character of the
+// account has it open.
+func (s *dbService) OpenStorage(accountId uint32, characterId uint32) (*Storage, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	insertQuery, insertArgs, err := psql.Insert("account_storage").
+		Columns("account_id").
+		Values(accountId).
+		Suffix("ON CONFLICT (account_id) DO NOTHING").
+		ToSql()
+	if err != nil {
+		s.logger.Error("Failed to build create storage query", shared.Field{Key: "error", Value: err})
+		return nil, err
+	}
+
+	if _, err := s.db.Exec(insertQuery, insertArgs...); err != nil {
+		s.logger.Error("Failed to execute create storage query", shared.Field{Key: "error", Value: err})
+		return nil, err
+	}
+
+	query, args, err := psql.Update("account_storage").
+		Set("locked_by", characterId).
+		Set("locked_at", sq.Expr("NOW()")).
+		Where(sq.And{
+			sq.Eq{"account_id": accountId},
+			sq.Or{
+				sq.Eq{"locked_by": nil},
+				sq.Eq{"locked_by": characterId},
+				sq.Expr(fmt.Sprintf("locked_at < NOW() - INTERVAL '%d seconds'", int(storageLockTimeout.Seconds()))),
+			},
+		}).
+		Suffix("RETURNING items, woonz, version").
+		ToSql()
+	if err != nil {
+		s.logger.Error("Failed to build open storage query", shared.Field{Key: "error", Value: err})
+		return nil, err
+	}
+
+	storage := &Storage{}
+	err = s.db.Get(storage, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrStorageLocked
+	}
+
+	if err != nil {
+		s.logger.Error("Failed to execute open storage query", shared.Field{Key: "error", Value: err})
+		return nil, err
+	}
+
+	return storage, nil
+}
+
+// CloseStorage releases the storage lock if the character still holds it.
+func (s *dbService) CloseStorage(accountId uint32, characterId uint32) error {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query, args, err := psql.Update("account_storage").
+		Set("locked_by", nil).
+		Set("locked_at", nil).
+		Where(sq.And{sq.Eq{"account_id": accountId}, sq.Eq{"locked_by": characterId}}).
+		ToSql()
+	if err != nil {
+		s.logger.Error("Failed to build close storage query", shared.Field{Key: "error", Value: err})
+		return err
+	}
+
+	if _, err := s.db.Exec(query, args...); err != nil {
+		s.logger.Error("Failed to execute close storage query", shared.Field{Key: "error", Value: err})
+		return err
+	}
+
+	return nil
+}
+
+// SaveCharacterAndStorage saves the character and the storage it has open in
+// one transaction and returns their new versions. The storage is only written
+// if it is still at the given version and locked by the character, otherwise
+// ErrStorageVersionConflict is returned and nothing is saved.
+func (s *dbService) SaveCharacterAndStorage(character CharacterSave, storage StorageSave) (uint64, uint64, error) {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		s.logger.Error("Failed to begin save storage transaction", shared.Field{Key: "error", Value: err})
+		return 0, 0, err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	characterVersion, err := s.saveCharacter(tx, character)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query, args, err := psql.Update("account_storage").
+		Set("items", storage.Items).
+		Set("woonz", storage.Woonz).
+		Set("version", sq.Expr("version + 1")).
+		Set("locked_at", sq.Expr("NOW()")).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.And{
+			sq.Eq{"account_id": storage.AccountId},
+			sq.Eq{"version": storage.Version},
+			sq.Eq{"locked_by": storage.CharacterId},
+		}).
+		Suffix("RETURNING version").
+		ToSql()
+	if err != nil {
+		s.logger.Error("Failed to build save storage query", shared.Field{Key: "error", Value: err})
+		return 0, 0, err
+	}
+
+	var storageVersion uint64
+	err = tx.Get(&storageVersion, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, 0, ErrStorageVersionConflict
+	}
+
+	if err != nil {
+		s.logger.Error("Failed to execute save storage query", shared.Field{Key: "error", Value: err})
+		return 0, 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("Failed to commit save storage transaction", shared.Field{Key: "error", Value: err})
+		return 0, 0, err
+	}
+
+	return characterVersion, storageVersion, nil
+}
@@ -0,0 +1,68 @@
+package zoneserver
+
+import (
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/data"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages"
+)
+
+// awardExp adds experience to the player and applies any level-ups it causes.
+func (z *Zone) awardExp(player *Player, exp uint32) {
+	levels := z.zoneManager.GetLevelTable()
+	if exp == 0 || player.IsDead() || player.Level >= levels.MaxLevel() {
+		return
+	}
+
+	classStats, _ := z.getClassStats(player, player.Class)
+	levelsGained := gainExp(player, exp, levels, classStats)
+	player.MarkDirty()
+	_ = player.Send(messages.NewMsgS2CExp(player.PcId, player.Exp).GetBytes())
+	if levelsGained == 0 {
+		return
+	}
+
+	z.recalculateStats(player)
+	player.Stats.HP = player.Stats.MaxHp
+	player.Stats.MP = player.Stats.MaxMp
+	levelUp := messages.NewMsgS2CLevelUp(
+		player.PcId,
+		player.PcId,
+		player.Level,
+		player.Stats.RemainingPoints,
+		player.Stats.HPCapacity,
+		player.Stats.MPCapacity,
+	).GetBytes()
+	z.BroadcastNearby(player.Location.X, player.Location.Y, levelUp, 0)
+	z.logger.Info(
+		"Player leveled up",
+		shared.Field{Key: "mapId", Value: z.mapId},
+		shared.Field{Key: "pcId", Value: player.PcId},
+		shared.Field{Key: "characterName", Value: player.CharacterName},
+		shared.Field{Key: "level", Value: player.Level},
+	)
+
+	z.savePlayer(player, nil)
+}
+
+// gainExp adds experience to the player, capped at what the highest level
+// needs, and raises their level once for every level the new total reaches.
+// It returns how many levels were gained.
+func gainExp(player *Player, exp uint32, levels data.LevelTable, classStats data.ClassStats) int {
+	maxExp, _ := levels.Exp(levels.MaxLevel())
+	player.Exp = uint32(min(uint64(player.Exp)+uint64(exp), uint64(maxExp)))
+	levelsGained := 0
+	for player.Level < levels.MaxLevel() {
+		nextExp, _ := levels.Exp(player.Level + 1)
+		if player.Exp < nextExp {
+			break
+		}
+
+		player.Level++
+		levelsGained++
+		player.Stats.RemainingPoints += classStats.StatPointsPerLevel
+		player.Stats.HPCapacity += classStats.HPPerLevel
+		player.Stats.MPCapacity += classStats.MPPerLevel
+	}
+
+	return levelsGained
+}
@@ -0,0 +1,85 @@
+package zoneserver
+
+import (
+	"testing"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/data"
+)
+
+func TestGainExp(t *testing.T) {
+	levels := loadTestLevelTable(t)
+	classStats := data.ClassStats{HPPerLevel: 6, MPPerLevel: 1, StatPointsPerLevel: 5}
+	tests := []struct {
+		name       string
+		level      uint16
+		exp        uint32
+		gain       uint32
+		wantLevel  uint16
+		wantExp    uint32
+		wantGained int
+	}{
+		{
+			name:      "not enough for the next level",
+			level:     1,
+			gain:      999,
+			wantLevel: 1,
+			wantExp:   999,
+		},
+		{
+			name:       "exactly enough for the next level",
+			level:      1,
+			gain:       1000,
+			wantLevel:  2,
+			wantExp:    1000,
+			wantGained: 1,
+		},
+		{
+			name:       "several levels at once",
+			level:      1,
+			exp:        500,
+			gain:       5000,
+			wantLevel:  3,
+			wantExp:    5500,
+			wantGained: 2,
+		},
+		{
+			name:       "capped at the highest level",
+			level:      4,
+			exp:        6000,
+			gain:       50000,
+			wantLevel:  5,
+			wantExp:    10000,
+			wantGained: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			player := &Player{Level: tt.level, Exp: tt.exp}
+			gained := gainExp(player, tt.gain, levels, classStats)
+			if gained != tt.wantGained || player.Level != tt.wantLevel || player.Exp != tt.wantExp {
+				t.Fatalf(
+					"gainExp() = %d, level %d, exp %d, want %d, level %d, exp %d",
+					gained, player.Level, player.Exp, tt.wantGained, tt.wantLevel, tt.wantExp,
+				)
+			}
+
+			gains := uint16(tt.wantGained)
+			if player.Stats.RemainingPoints != 5*gains || player.Stats.HPCapacity != 6*gains || player.Stats.MPCapacity != gains {
+				t.Errorf(
+					"got %d points, %d HP capacity and %d MP capacity after %d levels",
+					player.Stats.RemainingPoints, player.Stats.HPCapacity, player.Stats.MPCapacity, tt.wantGained,
+				)
+			}
+		})
+	}
+}
+
+func loadTestLevelTable(t *testing.T) data.LevelTable {
+	t.Helper()
+	levels, err := data.LoadLevelTable("testdata/level.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return levels
+}
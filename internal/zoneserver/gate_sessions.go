@@ -0,0 +1,30 @@
+package zoneserver
+
+import "github.com/project-agonyl/open-agonyl-servers/internal/shared"
+
+type GateSessions struct {
+	sessions *shared.SafeMap[byte, *zoneServerSession]
+}
+
+func NewGateSessions() *GateSessions {
+	return &GateSessions{
+		sessions: shared.NewSafeMap[byte, *zoneServerSession](),
+	}
+}
+
+func (g *GateSessions) Add(agentId byte, session *zoneServerSession) {
+	g.sessions.Set(agentId, session)
+}
+
+func (g *GateSessions) Remove(agentId byte, session *zoneServerSession) {
+	current, exists := g.sessions.Get(agentId)
+	if !exists || current != session {
+		return
+	}
+
+	g.sessions.Delete(agentId)
+}
+
+func (g *GateSessions) Get(agentId byte) (*zoneServerSession, bool) {
+	return g.sessions.Get(agentId)
+}
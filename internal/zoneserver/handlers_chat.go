@@ -0,0 +1,180 @@
+package zoneserver
+
+import (
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages"
+	"github.com/project-agonyl/open-agonyl-servers/internal/utils"
+)
+
+const (
+	chatTypeLocal   byte = 0x00
+	chatTypeShout   byte = 0x01
+	chatTypeWhisper byte = 0x02
+	chatTypeNation  byte = 0x03
+)
+
+func (z *Zone) handleSay(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SSay(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read say",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	message := utils.ReadStringFromBytes(msg.Message[:])
+	if message == "" {
+		return
+	}
+
+	switch msg.ChatType {
+	case chatTypeLocal:
+		say := messages.NewMsgS2CSay(player.PcId, chatTypeLocal, player.PcId, player.CharacterName, message).GetBytes()
+		z.BroadcastNearby(player.Location.X, player.Location.Y, say, player.PcId)
+	case chatTypeShout:
+		say := messages.NewMsgS2CSay(player.PcId, chatTypeShout, player.PcId, player.CharacterName, message).GetBytes()
+		z.broadcastZone(say, player.PcId, func(*Player) bool { return true })
+	case chatTypeNation:
+		nationChat := messages.NewMsgS2MNationChat(player.PcId, player.SocialInfo.Nation, player.CharacterName, message, player.GateServerSession.agentId)
+		if err := z.zoneManager.SendToMainServer(nationChat.GetBytes()); err != nil {
+			z.logger.Error(
+				"Failed to send nation chat to main server",
+				shared.Field{Key: "error", Value: err},
+				shared.Field{Key: "mapId", Value: z.mapId},
+				shared.Field{Key: "pcId", Value: player.PcId},
+			)
+		}
+	case chatTypeWhisper:
+		targetName := utils.ReadStringFromBytes(msg.TargetName[:])
+		if targetName == "" || targetName == player.CharacterName {
+			return
+		}
+
+		whisper := messages.NewMsgS2MWhisper(player.PcId, player.CharacterName, targetName, message, player.GateServerSession.agentId)
+		if err := z.zoneManager.SendToMainServer(whisper.GetBytes()); err != nil {
+			z.logger.Error(
+				"Failed to send whisper to main server",
+				shared.Field{Key: "error", Value: err},
+				shared.Field{Key: "mapId", Value: z.mapId},
+				shared.Field{Key: "pcId", Value: player.PcId},
+			)
+		}
+	default:
+		z.logger.Debug(
+			"Unknown chat type",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "pcId", Value: player.PcId},
+			shared.Field{Key: "chatType", Value: msg.ChatType},
+		)
+	}
+}
+
+func (z *Zone) handleChatWindowOpt(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SChatWindowOpt(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read chat window option",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	player.ChatWindowOption = msg.Option
+	player.MarkDirty()
+	_ = player.Send(messages.NewMsgS2CChatWindowOpt(player.PcId, player.ChatWindowOption).GetBytes())
+}
+
+// handleWhisper delivers a whisper the main server routed to a player in this
+// zone.
+func (z *Zone) handleWhisper(packet []byte) {
+	msg, err := messages.ReadMsgM2SWhisper(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read whisper",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "mapId", Value: z.mapId},
+		)
+		return
+	}
+
+	player, exists := z.players.Get(msg.PcId)
+	if !exists || player.Zone != z || player.State != PlayerStateInGame {
+		return
+	}
+
+	senderName := utils.ReadStringFromBytes(msg.SenderName[:])
+	message := utils.ReadStringFromBytes(msg.Message[:])
+	_ = player.Send(messages.NewMsgS2CSay(player.PcId, chatTypeWhisper, 0, senderName, message).GetBytes())
+}
+
+// handleNationChat delivers nation chat, which the main server sends to every
+// zone server, to the players of that nation in each zone of this server.
+func (c *MainServerClient) handleNationChat(packet []byte) {
+	msg, err := messages.ReadMsgM2SNationChat(packet)
+	if err != nil {
+		c.logger.Error(
+			"Failed to read nation chat",
+			shared.Field{Key: "error", Value: err},
+		)
+		return
+	}
+
+	senderName := utils.ReadStringFromBytes(msg.SenderName[:])
+	message := utils.ReadStringFromBytes(msg.Message[:])
+	say := messages.NewMsgS2CSay(msg.PcId, chatTypeNation, msg.PcId, senderName, message).GetBytes()
+	c.zoneManager.ForEachZone(func(zone *Zone) {
+		posted := zone.Post(func() {
+			zone.broadcastZone(say, msg.PcId, func(player *Player) bool {
+				return player.SocialInfo.Nation == msg.Nation
+			})
+		})
+		if !posted {
+			c.logger.Error(
+				"Failed to post nation chat",
+				shared.Field{Key: "mapId", Value: zone.mapId},
+				shared.Field{Key: "pcId", Value: msg.PcId},
+			)
+		}
+	})
+}
+
+// handleMainServerError passes an error raised by the main server on to the
+// player's client.
+func (z *Zone) handleMainServerError(packet []byte) {
+	msg, err := messages.ReadMsgM2SError(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read main server error",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "mapId", Value: z.mapId},
+		)
+		return
+	}
+
+	player, exists := z.players.Get(msg.PcId)
+	if !exists || player.Zone != z || player.GateServerSession == nil {
+		return
+	}
+
+	_ = player.GateServerSession.SendErrorMsg(player.PcId, msg.Code, utils.ReadStringFromBytes(msg.Msg[:]))
+}
+
+// broadcastZone relays the packet to every player in the zone accepted by
+// filter, except the player with exceptPcId.
+func (z *Zone) broadcastZone(packet []byte, exceptPcId uint32, filter func(*Player) bool) {
+	for _, pcId := range z.currentPlayers {
+		if pcId == exceptPcId {
+			continue
+		}
+
+		player, exists := z.players.Get(pcId)
+		if !exists || player.Zone != z || !filter(player) {
+			continue
+		}
+
+		z.relay(player, packet)
+	}
+}
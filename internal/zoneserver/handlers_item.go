@@ -0,0 +1,323 @@
+package zoneserver
+
+import (
+	"slices"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/data"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages/protocol"
+)
+
+const (
+	inventorySize = 0x1E
+	wearSlotCount = 0xA
+)
+
+const (
+	itemResultSuccess byte = 0x00
+	itemResultFailure byte = 0x01
+)
+
+// inventoryProtocols are the packets that could change a player's inventory
+// or Woonz. They are dropped while the inventory is locked by a trade or a
+// storage save.
+var inventoryProtocols = []uint16{
+	protocol.C2SPickupItem,
+	protocol.C2SDropItem,
+	protocol.C2SBuyItem,
+	protocol.C2SSellItem,
+	protocol.C2SMoveItem,
+	protocol.C2SWearItem,
+	protocol.C2SStripItem,
+	protocol.C2SHsWearItem,
+	protocol.C2SHsStripItem,
+	protocol.C2SWarp,
+	protocol.C2SAskWarpZ2B,
+	protocol.C2SAskWarpB2Z,
+	protocol.C2SAskOpenStorage,
+	protocol.C2SAskInven2Storage,
+	protocol.C2SAskStorage2Inven,
+	protocol.C2SAskMoveItemInStorage,
+	protocol.C2SAskDepositeMoney,
+	protocol.C2SAskWithdrawMoney,
+	protocol.C2SUsePotion,
+	protocol.C2SUsePotionEx,
+	protocol.C2SUseItem,
+	protocol.C2SLearnSkill,
+	protocol.C2SRetrievePoint,
+	protocol.C2SHsRetrievePoint,
+}
+
+// isInventoryLocked reports whether the player's inventory is held by a trade
+// or a storage save that has not finished.
+func (z *Zone) isInventoryLocked(player *Player) bool {
+	return z.trades[player.PcId] != nil || (player.storage != nil && player.storage.busy)
+}
+
+func (z *Zone) handleMoveItem(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SMoveItem(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read move item",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	result := itemResultFailure
+	if moveInventoryItem(player.Inventory, msg.FromSlot, msg.ToSlot, inventorySize) {
+		result = itemResultSuccess
+		player.MarkDirty()
+	}
+
+	_ = player.Send(messages.NewMsgS2CMoveItem(player.PcId, msg.FromSlot, msg.ToSlot, result).GetBytes())
+}
+
+func (z *Zone) handleWearItem(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SWearItem(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read wear item",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	wearIndex, ok := z.wearItem(&player.Inventory, &player.Wear, msg.Slot, player.Class, player.Level, player.Stats)
+	if !ok {
+		_ = player.Send(messages.NewMsgS2CWearItem(player.PcId, msg.Slot, 0, itemResultFailure).GetBytes())
+		return
+	}
+
+	_ = player.Send(messages.NewMsgS2CWearItem(player.PcId, msg.Slot, wearIndex, itemResultSuccess).GetBytes())
+	z.updatePlayerWear(player)
+}
+
+func (z *Zone) handleStripItem(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SStripItem(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read strip item",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	slot, ok := stripItem(&player.Inventory, &player.Wear, msg.WearIndex)
+	if !ok {
+		_ = player.Send(messages.NewMsgS2CStripItem(player.PcId, msg.WearIndex, 0, itemResultFailure).GetBytes())
+		return
+	}
+
+	_ = player.Send(messages.NewMsgS2CStripItem(player.PcId, msg.WearIndex, slot, itemResultSuccess).GetBytes())
+	z.updatePlayerWear(player)
+}
+
+// handleHsWearItem puts an item from the player's inventory on the player's
+// mercenary. Mercenaries wear items made for their class, at their owner's
+// level.
+func (z *Zone) handleHsWearItem(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SHsWearItem(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read hs wear item",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	mercenary := z.getMercenary(player, msg.HsId)
+	if mercenary == nil {
+		_ = player.Send(messages.NewMsgS2CHsWearItem(player.PcId, msg.HsId, msg.Slot, 0, itemResultFailure).GetBytes())
+		return
+	}
+
+	wearIndex, ok := z.wearItem(&player.Inventory, &mercenary.Wear, msg.Slot, mercenaryClass, player.Level, mercenary.Stats)
+	if !ok {
+		_ = player.Send(messages.NewMsgS2CHsWearItem(player.PcId, msg.HsId, msg.Slot, 0, itemResultFailure).GetBytes())
+		return
+	}
+
+	z.recalculateStats(player)
+	player.MarkDirty()
+	_ = player.Send(messages.NewMsgS2CHsWearItem(player.PcId, msg.HsId, msg.Slot, wearIndex, itemResultSuccess).GetBytes())
+}
+
+func (z *Zone) handleHsStripItem(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SHsStripItem(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read hs strip item",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	mercenary := z.getMercenary(player, msg.HsId)
+	if mercenary == nil {
+		_ = player.Send(messages.NewMsgS2CHsStripItem(player.PcId, msg.HsId, msg.WearIndex, 0, itemResultFailure).GetBytes())
+		return
+	}
+
+	slot, ok := stripItem(&player.Inventory, &mercenary.Wear, msg.WearIndex)
+	if !ok {
+		_ = player.Send(messages.NewMsgS2CHsStripItem(player.PcId, msg.HsId, msg.WearIndex, 0, itemResultFailure).GetBytes())
+		return
+	}
+
+	z.recalculateStats(player)
+	player.MarkDirty()
+	_ = player.Send(messages.NewMsgS2CHsStripItem(player.PcId, msg.HsId, msg.WearIndex, slot, itemResultSuccess).GetBytes())
+}
+
+// updatePlayerWear applies a change of worn items: stats are recalculated and
+// sent to the player, and everyone around sees the new look.
+func (z *Zone) updatePlayerWear(player *Player) {
+	player.MarkDirty()
+	z.recalculateStats(player)
+	_ = player.Send(newPcStatsMsg(player).GetBytes())
+	appear := z.newPcAppearMsg(player).GetBytes()
+	z.BroadcastNearby(player.Location.X, player.Location.Y, appear, player.PcId)
+}
+
+// wearItem moves the inventory item in slot to the wear index its item data
+// gives. An item already worn there goes back to the freed inventory slot.
+func (z *Zone) wearItem(
+	inventory *[]InventoryItem,
+	wear *[]WearItem,
+	slot byte,
+	class byte,
+	level uint16,
+	stats Stats,
+) (byte, bool) {
+	inventoryIndex := findInventorySlot(*inventory, slot)
+	if inventoryIndex < 0 {
+		return 0, false
+	}
+
+	item := (*inventory)[inventoryIndex]
+	itemData, err := z.zoneManager.GetItemData(item.ItemCode)
+	if err != nil || !canWearItem(itemData, item.ItemOption, class, level, stats) {
+		return 0, false
+	}
+
+	wearItem := WearItem{
+		ItemCode:       item.ItemCode,
+		ItemOption:     item.ItemOption,
+		ItemUniqueCode: item.ItemUniqueCode,
+		WearIndex:      itemData.SlotIndex,
+	}
+	if wearIndex := findWearIndex(*wear, itemData.SlotIndex); wearIndex >= 0 {
+		worn := (*wear)[wearIndex]
+		(*inventory)[inventoryIndex] = InventoryItem{
+			ItemCode:       worn.ItemCode,
+			ItemOption:     worn.ItemOption,
+			ItemUniqueCode: worn.ItemUniqueCode,
+			Slot:           slot,
+		}
+		(*wear)[wearIndex] = wearItem
+		return itemData.SlotIndex, true
+	}
+
+	*inventory = slices.Delete(*inventory, inventoryIndex, inventoryIndex+1)
+	*wear = append(*wear, wearItem)
+	return itemData.SlotIndex, true
+}
+
+// stripItem moves the item worn at wearIndex to the first free inventory
+// slot.
+func stripItem(inventory *[]InventoryItem, wear *[]WearItem, wearIndex byte) (byte, bool) {
+	index := findWearIndex(*wear, wearIndex)
+	if index < 0 {
+		return 0, false
+	}
+
+	slot, ok := freeInventorySlot(*inventory)
+	if !ok {
+		return 0, false
+	}
+
+	worn := (*wear)[index]
+	*wear = slices.Delete(*wear, index, index+1)
+	*inventory = append(*inventory, InventoryItem{
+		ItemCode:       worn.ItemCode,
+		ItemOption:     worn.ItemOption,
+		ItemUniqueCode: worn.ItemUniqueCode,
+		Slot:           slot,
+	})
+	return slot, true
+}
+
+// moveInventoryItem moves the item in from to the slot to, swapping it with
+// the item already there. It is also used for the storage, which has its own
+// number of slots.
+func moveInventoryItem(inventory []InventoryItem, from byte, to byte, slotCount byte) bool {
+	if from == to || to >= slotCount {
+		return false
+	}
+
+	fromIndex := findInventorySlot(inventory, from)
+	if fromIndex < 0 {
+		return false
+	}
+
+	if toIndex := findInventorySlot(inventory, to); toIndex >= 0 {
+		inventory[toIndex].Slot = from
+	}
+
+	inventory[fromIndex].Slot = to
+	return true
+}
+
+// canWearItem checks the wear slot of the item and what its data requires of
+// the wearer: the class and the base stats for the upgrade level of IT0
+// items, and a level for IT1 items. IT1 items have no class in their data,
+// so every class can wear them. Only IT0 and IT1 items can be worn, IT2 items
+// are skill books.
+func canWearItem(itemData *data.Item, itemOption uint32, class byte, level uint16, stats Stats) bool {
+	if itemData.SlotIndex >= wearSlotCount {
+		return false
+	}
+
+	switch {
+	case itemData.IT0Property != nil && len(itemData.IT0Property.Levels) > 0:
+		levels := itemData.IT0Property.Levels
+		required := levels[min(int(itemOption&0xFF), len(levels)-1)]
+		return uint16(class) == itemData.IT0Property.Class &&
+			stats.Strength >= required.Strength &&
+			stats.Intelligence >= required.Intelligence &&
+			stats.Dexterity >= required.Dexterity
+	case itemData.IT1Property != nil:
+		return level >= itemData.IT1Property.RequiredLevel
+	}
+
+	return false
+}
+
+func findInventorySlot(inventory []InventoryItem, slot byte) int {
+	return slices.IndexFunc(inventory, func(item InventoryItem) bool {
+		return item.Slot == slot
+	})
+}
+
+func findWearIndex(wear []WearItem, wearIndex byte) int {
+	return slices.IndexFunc(wear, func(item WearItem) bool {
+		return item.WearIndex == wearIndex
+	})
+}
+
+func freeInventorySlot(inventory []InventoryItem) (byte, bool) {
+	for slot := byte(0); slot < inventorySize; slot++ {
+		if findInventorySlot(inventory, slot) < 0 {
+			return slot, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,255 @@
+package zoneserver
+
+import (
+	"testing"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/constants"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/data"
+)
+
+const (
+	testSword uint32 = iota + 1
+	testAxe
+	testStaff
+	testRing
+	testSkillBook
+	testBadSlot
+)
+
+func newTestItemZone() *Zone {
+	warriorLevels := []data.IT0Level{{Strength: 20}, {Strength: 40}}
+	items := map[uint32]*data.Item{
+		testSword:     {ItemCode: testSword, SlotIndex: 0, IT0Property: &data.IT0Property{Class: uint16(constants.ClassWarrior), Levels: warriorLevels}},
+		testAxe:       {ItemCode: testAxe, SlotIndex: 0, IT0Property: &data.IT0Property{Class: uint16(constants.ClassWarrior), Levels: warriorLevels}},
+		testStaff:     {ItemCode: testStaff, SlotIndex: 0, IT0Property: &data.IT0Property{Class: uint16(constants.ClassMage), Levels: []data.IT0Level{{}}}},
+		testRing:      {ItemCode: testRing, SlotIndex: 9, IT1Property: &data.IT1Property{RequiredLevel: 10}},
+		testSkillBook: {ItemCode: testSkillBook, IT2Property: &data.IT2Property{Class: uint16(constants.ClassWarrior)}},
+		testBadSlot:   {ItemCode: testBadSlot, SlotIndex: wearSlotCount, IT1Property: &data.IT1Property{}},
+	}
+
+	return &Zone{zoneManager: &ZoneManager{itemsData: items}}
+}
+
+func TestWearItem(t *testing.T) {
+	warrior := Stats{Strength: 30}
+	tests := []struct {
+		name          string
+		inventory     []InventoryItem
+		wear          []WearItem
+		slot          byte
+		class         byte
+		level         uint16
+		stats         Stats
+		wantOk        bool
+		wantWorn      uint32
+		wantWearIndex byte
+		wantInventory []InventoryItem
+	}{
+		{
+			name:          "wears into a free wear slot",
+			inventory:     []InventoryItem{{ItemCode: testSword, Slot: 3}},
+			slot:          3,
+			class:         constants.ClassWarrior,
+			stats:         warrior,
+			wantOk:        true,
+			wantWorn:      testSword,
+			wantInventory: []InventoryItem{},
+		},
+		{
+			name:          "slot conflict swaps with the worn item",
+			inventory:     []InventoryItem{{ItemCode: testAxe, Slot: 3}},
+			wear:          []WearItem{{ItemCode: testSword, WearIndex: 0}},
+			slot:          3,
+			class:         constants.ClassWarrior,
+			stats:         warrior,
+			wantOk:        true,
+			wantWorn:      testAxe,
+			wantInventory: []InventoryItem{{ItemCode: testSword, Slot: 3}},
+		},
+		{
+			name:      "wrong class",
+			inventory: []InventoryItem{{ItemCode: testStaff, Slot: 3}},
+			slot:      3,
+			class:     constants.ClassWarrior,
+			stats:     warrior,
+		},
+		{
+			name:      "base stats too low for the upgrade level",
+			inventory: []InventoryItem{{ItemCode: testSword, ItemOption: 1, Slot: 3}},
+			slot:      3,
+			class:     constants.ClassWarrior,
+			stats:     warrior,
+		},
+		{
+			name:      "under the required level",
+			inventory: []InventoryItem{{ItemCode: testRing, Slot: 3}},
+			slot:      3,
+			class:     constants.ClassWarrior,
+			level:     9,
+		},
+		{
+			name:          "at the required level",
+			inventory:     []InventoryItem{{ItemCode: testRing, Slot: 3}},
+			slot:          3,
+			class:         constants.ClassMage,
+			level:         10,
+			wantOk:        true,
+			wantWorn:      testRing,
+			wantWearIndex: 9,
+			wantInventory: []InventoryItem{},
+		},
+		{
+			name:      "skill books cannot be worn",
+			inventory: []InventoryItem{{ItemCode: testSkillBook, Slot: 3}},
+			slot:      3,
+			class:     constants.ClassWarrior,
+			level:     100,
+		},
+		{
+			name:      "no wear slot",
+			inventory: []InventoryItem{{ItemCode: testBadSlot, Slot: 3}},
+			slot:      3,
+			class:     constants.ClassWarrior,
+		},
+		{
+			name:      "empty inventory slot",
+			inventory: []InventoryItem{{ItemCode: testSword, Slot: 3}},
+			slot:      4,
+			class:     constants.ClassWarrior,
+			stats:     warrior,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			z := newTestItemZone()
+			inventory := append([]InventoryItem(nil), tt.inventory...)
+			wear := append([]WearItem(nil), tt.wear...)
+			wearIndex, ok := z.wearItem(&inventory, &wear, tt.slot, tt.class, tt.level, tt.stats)
+			if ok != tt.wantOk {
+				t.Fatalf("wearItem() ok = %v, want %v", ok, tt.wantOk)
+			}
+
+			if !ok {
+				if len(inventory) != len(tt.inventory) || len(wear) != len(tt.wear) {
+					t.Fatal("a failed wearItem() changed the inventory or wear")
+				}
+
+				return
+			}
+
+			index := findWearIndex(wear, tt.wantWearIndex)
+			if wearIndex != tt.wantWearIndex || index < 0 || wear[index].ItemCode != tt.wantWorn {
+				t.Fatalf("wear = %v, want item %d worn", wear, tt.wantWorn)
+			}
+
+			if len(inventory) != len(tt.wantInventory) {
+				t.Fatalf("inventory = %v, want %v", inventory, tt.wantInventory)
+			}
+
+			for i := range inventory {
+				if inventory[i].ItemCode != tt.wantInventory[i].ItemCode || inventory[i].Slot != tt.wantInventory[i].Slot {
+					t.Fatalf("inventory = %v, want %v", inventory, tt.wantInventory)
+				}
+			}
+		})
+	}
+}
+
+func TestStripItem(t *testing.T) {
+	fullInventory := make([]InventoryItem, inventorySize)
+	for i := range fullInventory {
+		fullInventory[i] = InventoryItem{ItemCode: testRing, Slot: byte(i)}
+	}
+
+	tests := []struct {
+		name      string
+		inventory []InventoryItem
+		wearIndex byte
+		wantOk    bool
+		wantSlot  byte
+	}{
+		{"goes to the first free slot", []InventoryItem{{ItemCode: testRing, Slot: 0}}, 0, true, 1},
+		{"full inventory", fullInventory, 0, false, 0},
+		{"nothing worn there", nil, 5, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inventory := append([]InventoryItem(nil), tt.inventory...)
+			wear := []WearItem{{ItemCode: testSword, WearIndex: 0}}
+			slot, ok := stripItem(&inventory, &wear, tt.wearIndex)
+			if ok != tt.wantOk {
+				t.Fatalf("stripItem() ok = %v, want %v", ok, tt.wantOk)
+			}
+
+			if !ok {
+				if len(wear) != 1 || len(inventory) != len(tt.inventory) {
+					t.Fatal("a failed stripItem() changed the inventory or wear")
+				}
+
+				return
+			}
+
+			if slot != tt.wantSlot || len(wear) != 0 {
+				t.Fatalf("stripItem() slot = %d, wear = %v, want slot %d and nothing worn", slot, wear, tt.wantSlot)
+			}
+
+			if index := findInventorySlot(inventory, slot); index < 0 || inventory[index].ItemCode != testSword {
+				t.Fatalf("stripped item is not in slot %d: %v", slot, inventory)
+			}
+		})
+	}
+}
+
+func TestMoveInventoryItem(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to byte
+		want     bool
+		wantA    byte
+		wantB    byte
+	}{
+		{"to an empty slot", 0, 5, true, 5, 1},
+		{"swaps with the item there", 0, 1, true, 1, 0},
+		{"same slot", 0, 0, false, 0, 1},
+		{"nothing in the source slot", 2, 3, false, 0, 1},
+		{"past the last slot", 0, inventorySize, false, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inventory := []InventoryItem{{ItemCode: testSword, Slot: 0}, {ItemCode: testRing, Slot: 1}}
+			if got := moveInventoryItem(inventory, tt.from, tt.to, inventorySize); got != tt.want {
+				t.Fatalf("moveInventoryItem() = %v, want %v", got, tt.want)
+			}
+
+			if inventory[0].Slot != tt.wantA || inventory[1].Slot != tt.wantB {
+				t.Fatalf("slots = %d and %d, want %d and %d", inventory[0].Slot, inventory[1].Slot, tt.wantA, tt.wantB)
+			}
+		})
+	}
+}
+
+func TestFreeInventorySlot(t *testing.T) {
+	full := make([]InventoryItem, inventorySize)
+	for i := range full {
+		full[i] = InventoryItem{Slot: byte(i)}
+	}
+
+	tests := []struct {
+		name      string
+		inventory []InventoryItem
+		want      byte
+		wantOk    bool
+	}{
+		{"empty inventory", nil, 0, true},
+		{"gap between items", []InventoryItem{{Slot: 0}, {Slot: 2}}, 1, true},
+		{"full inventory", full, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slot, ok := freeInventorySlot(tt.inventory)
+			if slot != tt.want || ok != tt.wantOk {
+				t.Fatalf("freeInventorySlot() = %d, %v, want %d, %v", slot, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package zoneserver
+
+import (
+	"slices"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/constants"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages"
+)
+
+func (z *Zone) handlePlayerLogin(player *Player) {
+	if player.GateServerSession == nil {
+		z.logger.Error(
+			"Player has no gate server session",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		z.players.Remove(player.PcId)
+		return
+	}
+
+	z.placeMercenary(player)
+	z.recalculateStats(player)
+	msg := z.newWorldLoginMsg(player)
+	if err := player.Send(msg.GetBytes()); err != nil {
+		z.logger.Error(
+			"Failed to send world login",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		_ = player.GateServerSession.SendErrorMsg(player.PcId, constants.ErrorCodeGenericFailure, constants.ThereWasAnIssueLoggingInMsg)
+		z.players.Remove(player.PcId)
+		return
+	}
+
+	_ = player.Send(messages.NewMsgS2CChatWindowOpt(player.PcId, player.ChatWindowOption).GetBytes())
+	_ = player.Send(messages.NewMsgS2CSkillSlotInfo(player.PcId, player.SkillSlots).GetBytes())
+	player.State = PlayerStateInGame
+	z.currentPlayers = append(z.currentPlayers, player.PcId)
+	z.addPlayerToWorld(player)
+	z.sendPartyUpdate(player, true)
+	z.logger.Info(
+		"Player entered zone",
+		shared.Field{Key: "mapId", Value: z.mapId},
+		shared.Field{Key: "pcId", Value: player.PcId},
+		shared.Field{Key: "characterName", Value: player.CharacterName},
+	)
+}
+
+// handlePlayerLogout takes the player out of the world, makes a final save
+// and only then releases the player and tells the main server, so that a
+// quick re-login cannot load stale data.
+func (z *Zone) handlePlayerLogout(player *Player) {
+	if player.State == PlayerStateLoggingOut {
+		return
+	}
+
+	z.cancelPlayerTrade(player)
+	z.closeStorage(player)
+	if player.State == PlayerStateInGame {
+		z.removePlayerFromWorld(player)
+		z.currentPlayers = slices.DeleteFunc(z.currentPlayers, func(pcId uint32) bool {
+			return pcId == player.PcId
+		})
+	}
+
+	player.State = PlayerStateLoggingOut
+	player.GateServerSession = nil
+	z.savePlayer(player, func(err error) {
+		if err != nil {
+			z.logger.Error(
+				"Final character save failed, changes since the last save are lost",
+				shared.Field{Key: "error", Value: err},
+				shared.Field{Key: "mapId", Value: z.mapId},
+				shared.Field{Key: "pcId", Value: player.PcId},
+				shared.Field{Key: "characterId", Value: player.CharacterId},
+			)
+		}
+
+		z.players.Remove(player.PcId)
+		msg := messages.NewMsgS2MCharacterLogout(player.PcId, player.CharacterName)
+		if err := z.zoneManager.SendToMainServer(msg.GetBytes()); err != nil {
+			z.logger.Error(
+				"Failed to send character logout to main server",
+				shared.Field{Key: "error", Value: err},
+				shared.Field{Key: "mapId", Value: z.mapId},
+				shared.Field{Key: "pcId", Value: player.PcId},
+			)
+		}
+
+		z.logger.Info(
+			"Player left zone",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "pcId", Value: player.PcId},
+			shared.Field{Key: "characterName", Value: player.CharacterName},
+		)
+	})
+}
+
+func (z *Zone) newWorldLoginMsg(player *Player) *messages.MsgS2CWorldLogin {
+	msg := messages.NewMsgS2CWorldLogin(player.PcId, player.CharacterName)
+	msg.Class = player.Class
+	msg.Level = player.Level
+	msg.Exp = player.Exp
+	msg.MapIndex = uint32(z.mapId)
+	msg.MapCell = player.Location.Cell()
+	for _, skill := range player.Skills {
+		if skill.Id >= 32 {
+			continue
+		}
+
+		switch skill.Level {
+		case 1:
+			msg.Skill.LevelOneSkills |= 1 << skill.Id
+		case 2:
+			msg.Skill.LevelTwoSkills |= 1 << skill.Id
+		case 3:
+			msg.Skill.LevelThreeSkills |= 1 << skill.Id
+		}
+	}
+
+	msg.PKCount = player.PKCount
+	msg.RTime = player.RTime
+	msg.SocialInfo = newSocialInfoMsg(player.SocialInfo)
+	msg.Woonz = player.Woonz
+	msg.Lore = player.Lore
+	msg.RemainingPoints = player.Stats.RemainingPoints
+	msg.Strength = player.Stats.Strength
+	msg.Intelligence = player.Stats.Intelligence
+	msg.Dexterity = player.Stats.Dexterity
+	msg.Vitality = player.Stats.Vitality
+	msg.Mana = player.Stats.Mana
+	msg.HPCapacity = uint32(player.Stats.HPCapacity)
+	msg.MPCapacity = uint32(player.Stats.MPCapacity)
+	msg.HP = player.Stats.HP
+	msg.MP = player.Stats.MP
+	msg.HitAttack = player.Stats.HitAttack
+	msg.MagicAttack = player.Stats.MagicAttack
+	msg.Defense = player.Stats.Defense
+	msg.FireAttack = player.Stats.FireAttack
+	msg.FireDefence = player.Stats.FireDefence
+	msg.IceAttack = player.Stats.IceAttack
+	msg.IceDefense = player.Stats.IceDefense
+	msg.LightAttack = player.Stats.LightAttack
+	msg.LightDefense = player.Stats.LightDefense
+	msg.MaxHp = player.Stats.MaxHp
+	msg.MaxMp = player.Stats.MaxMp
+	msg.AdditionalHitAttack = player.Stats.AdditionalHitAttack
+	msg.AdditionalMagicAttack = player.Stats.AdditionalMagicAttack
+	msg.WearList = newWearListMsg(player.Wear)
+
+	for i, invItem := range player.Inventory {
+		if i >= len(msg.CharacterInventory) {
+			break
+		}
+
+		msg.CharacterInventory[i] = messages.CharacterInventory{
+			Item: messages.Item{
+				ItemCode:       invItem.ItemCode,
+				ItemOption:     invItem.ItemOption,
+				ItemUniqueCode: invItem.ItemUniqueCode,
+			},
+			Slot: uint32(invItem.Slot),
+		}
+	}
+
+	msg.ActivePet = newPetMsg(player.ActivePet)
+	for i, petInv := range player.PetInventory {
+		if i >= len(msg.PetInventory) {
+			break
+		}
+
+		msg.PetInventory[i] = newPetMsg(petInv.Pet)
+	}
+
+	return msg
+}
+
+func newPetMsg(pet Pet) messages.Pet {
+	return messages.Pet{
+		PetCode:       pet.PetCode,
+		Option1:       pet.PetHP,
+		Option2:       pet.PetOption,
+		PetUniqueCode: pet.PetUniqueCode,
+	}
+}
+
+func newSocialInfoMsg(socialInfo SocialInfo) messages.SocialInfo {
+	return messages.SocialInfo{
+		KHRank: uint32(socialInfo.KHRank),
+		KHId:   socialInfo.KHId,
+		Nation: uint32(socialInfo.Nation),
+	}
+}
+
+func newWearListMsg(wear []WearItem) [0xA]messages.CharacterWear {
+	var wearList [0xA]messages.CharacterWear
+	for i, wearItem := range wear {
+		if i >= len(wearList) {
+			break
+		}
+
+		wearList[i] = messages.CharacterWear{
+			Item: messages.Item{
+				ItemCode:       wearItem.ItemCode,
+				ItemOption:     wearItem.ItemOption,
+				ItemUniqueCode: wearItem.ItemUniqueCode,
+			},
+			WearIndex: uint32(wearItem.WearIndex),
+		}
+	}
+
+	return wearList
+}
@@ -0,0 +1,261 @@
+package zoneserver
+
+import (
+	"time"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages"
+	"github.com/project-agonyl/open-agonyl-servers/internal/zoneserver/pathfinding"
+)
+
+const (
+	maxMoveCellsPerSecond = 8
+	moveDistanceTolerance = 2
+	// maxMoveBudget caps the cells a move can save up while standing still,
+	// so a pause cannot be spent on one long step.
+	maxMoveBudget = maxMoveCellsPerSecond + moveDistanceTolerance
+)
+
+func (z *Zone) handleAskMove(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SAskMove(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read ask move",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	path, ok := z.findMovePath(player.Location, msg.StartCell, msg.TargetCell)
+	if !ok {
+		z.fixPlayerMove(player)
+		return
+	}
+
+	targetX, targetY := CellToXY(msg.TargetCell)
+	startMove(&player.Movement, player.Location, path, targetX, targetY)
+	startCell := player.Location.Cell()
+	_ = player.Send(messages.NewMsgS2CAnsMove(player.PcId, player.PcId, startCell, msg.TargetCell).GetBytes())
+	seeMove := messages.NewMsgS2CSeeMove(player.PcId, player.PcId, startCell, msg.TargetCell).GetBytes()
+	z.BroadcastNearby(player.Location.X, player.Location.Y, seeMove, player.PcId)
+}
+
+func (z *Zone) handlePcMove(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SPcMove(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read pc move",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	now := time.Now()
+	pathIndex, ok := z.isValidMoveStep(player.Location, player.Movement, msg.Cell, now)
+	if !ok {
+		z.logger.Warn(
+			"Rejected player move",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "pcId", Value: player.PcId},
+			shared.Field{Key: "fromCell", Value: player.Location.Cell()},
+			shared.Field{Key: "toCell", Value: msg.Cell},
+		)
+		z.fixPlayerMove(player)
+		return
+	}
+
+	x, y := CellToXY(msg.Cell)
+	takeMoveStep(&player.Movement, cellDistance(player.Location.X, player.Location.Y, x, y), pathIndex, now)
+	z.movePlayerInWorld(player, x, y)
+	if msg.Stop != 0 || (x == player.Movement.Target.X && y == player.Movement.Target.Y) {
+		player.Movement.IsMoving = false
+		seeStop := messages.NewMsgS2CSeeStop(player.PcId, player.PcId, msg.Cell).GetBytes()
+		z.BroadcastNearby(x, y, seeStop, player.PcId)
+	}
+}
+
+func (z *Zone) handleAskHsMove(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SAskHsMove(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read ask hs move",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	mercenary := z.getMercenary(player, msg.HsId)
+	if mercenary == nil {
+		return
+	}
+
+	path, ok := z.findMovePath(mercenary.Location, msg.StartCell, msg.TargetCell)
+	if !ok {
+		z.fixMercenaryMove(player, mercenary)
+		return
+	}
+
+	targetX, targetY := CellToXY(msg.TargetCell)
+	startMove(&mercenary.Movement, mercenary.Location, path, targetX, targetY)
+	startCell := mercenary.Location.Cell()
+	_ = player.Send(messages.NewMsgS2CAnsMove(player.PcId, mercenary.Id, startCell, msg.TargetCell).GetBytes())
+	seeMove := messages.NewMsgS2CSeeMove(player.PcId, mercenary.Id, startCell, msg.TargetCell).GetBytes()
+	z.BroadcastNearby(mercenary.Location.X, mercenary.Location.Y, seeMove, player.PcId)
+}
+
+func (z *Zone) handleHsMove(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SHsMove(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read hs move",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	mercenary := z.getMercenary(player, msg.HsId)
+	if mercenary == nil {
+		return
+	}
+
+	now := time.Now()
+	pathIndex, ok := z.isValidMoveStep(mercenary.Location, mercenary.Movement, msg.Cell, now)
+	if !ok {
+		z.logger.Warn(
+			"Rejected mercenary move",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "pcId", Value: player.PcId},
+			shared.Field{Key: "hsId", Value: mercenary.Id},
+			shared.Field{Key: "fromCell", Value: mercenary.Location.Cell()},
+			shared.Field{Key: "toCell", Value: msg.Cell},
+		)
+		z.fixMercenaryMove(player, mercenary)
+		return
+	}
+
+	x, y := CellToXY(msg.Cell)
+	takeMoveStep(&mercenary.Movement, cellDistance(mercenary.Location.X, mercenary.Location.Y, x, y), pathIndex, now)
+	mercenary.Location.X, mercenary.Location.Y = x, y
+	if msg.Stop != 0 || mercenary.Location == mercenary.Movement.Target {
+		mercenary.Movement.IsMoving = false
+		seeStop := messages.NewMsgS2CSeeStop(player.PcId, mercenary.Id, msg.Cell).GetBytes()
+		z.BroadcastNearby(mercenary.Location.X, mercenary.Location.Y, seeStop, player.PcId)
+	}
+}
+
+func (z *Zone) getMercenary(player *Player, hsId uint32) *Mercenary {
+	if player.Mercenary == nil || player.Mercenary.Id != hsId {
+		z.logger.Debug(
+			"Player has no such mercenary",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "pcId", Value: player.PcId},
+			shared.Field{Key: "hsId", Value: hsId},
+		)
+		return nil
+	}
+
+	return player.Mercenary
+}
+
+// startMove sets off along path. The budget carries over from the last
+// move, so stopping and starting again does not earn any extra cells.
+func startMove(movement *Movement, location Location, path []pathfinding.Point, targetX byte, targetY byte) {
+	movement.IsMoving = true
+	movement.Target = Location{MapId: location.MapId, X: targetX, Y: targetY}
+	movement.Path = path
+	movement.PathIndex = 0
+}
+
+// fixPlayerMove stops the player at its server side location and snaps the
+// client and everyone around it back to that cell.
+func (z *Zone) fixPlayerMove(player *Player) {
+	player.Movement.IsMoving = false
+	cell := player.Location.Cell()
+	_ = player.Send(messages.NewMsgS2CFixMove(player.PcId, player.PcId, cell).GetBytes())
+	seeStop := messages.NewMsgS2CSeeStop(player.PcId, player.PcId, cell).GetBytes()
+	z.BroadcastNearby(player.Location.X, player.Location.Y, seeStop, player.PcId)
+}
+
+func (z *Zone) fixMercenaryMove(player *Player, mercenary *Mercenary) {
+	mercenary.Movement.IsMoving = false
+	cell := mercenary.Location.Cell()
+	_ = player.Send(messages.NewMsgS2CFixMove(player.PcId, mercenary.Id, cell).GetBytes())
+	seeStop := messages.NewMsgS2CSeeStop(player.PcId, mercenary.Id, cell).GetBytes()
+	z.BroadcastNearby(mercenary.Location.X, mercenary.Location.Y, seeStop, player.PcId)
+}
+
+// findMovePath checks that a move starts where the server has the mover and
+// returns the path to its target.
+func (z *Zone) findMovePath(location Location, startCell uint32, targetCell uint32) ([]pathfinding.Point, bool) {
+	startX, startY := CellToXY(startCell)
+	targetX, targetY := CellToXY(targetCell)
+	if cellDistance(location.X, location.Y, startX, startY) > moveDistanceTolerance {
+		return nil, false
+	}
+
+	path, err := z.pathfinder.FindPath(pathfinding.Point{X: location.X, Y: location.Y}, pathfinding.Point{X: targetX, Y: targetY})
+	return path, err == nil
+}
+
+// isValidMoveStep checks that a reported step stays on the path of the move,
+// does not cross any blocked cell and is no longer than the move budget
+// allows. It returns how far along the path the step got.
+func (z *Zone) isValidMoveStep(location Location, movement Movement, cell uint32, now time.Time) (int, bool) {
+	if !movement.IsMoving {
+		return 0, false
+	}
+
+	x, y := CellToXY(cell)
+	pathIndex, onPath := followMovePath(movement, x, y)
+	if !onPath || float64(cellDistance(location.X, location.Y, x, y)) > moveBudget(movement, now) {
+		return 0, false
+	}
+
+	return pathIndex, z.pathfinder.HasLineOfSight(pathfinding.Point{X: location.X, Y: location.Y}, pathfinding.Point{X: x, Y: y})
+}
+
+// followMovePath returns the index of the first cell of the path still ahead
+// that is within moveDistanceTolerance of x, y. The client finds its own
+// path, so steps may stray from the one the server found by a little, but
+// cannot leave it or go back along it.
+func followMovePath(movement Movement, x byte, y byte) (int, bool) {
+	for i := movement.PathIndex; i < len(movement.Path); i++ {
+		if cellDistance(movement.Path[i].X, movement.Path[i].Y, x, y) <= moveDistanceTolerance {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// moveBudget returns how many cells the mover may cover in a step taken at
+// now: what was left after the last step plus what the time since allows,
+// up to maxMoveBudget.
+func moveBudget(movement Movement, now time.Time) float64 {
+	earned := now.Sub(movement.BudgetAt).Seconds() * maxMoveCellsPerSecond
+	return min(movement.Budget+earned, maxMoveBudget)
+}
+
+// takeMoveStep spends the distance of an accepted step from the budget and
+// moves on along the path.
+func takeMoveStep(movement *Movement, distance int, pathIndex int, now time.Time) {
+	movement.Budget = moveBudget(*movement, now) - float64(distance)
+	movement.BudgetAt = now
+	movement.PathIndex = pathIndex
+}
+
+func cellDistance(fromX byte, fromY byte, toX byte, toY byte) int {
+	return max(abs(int(toX)-int(fromX)), abs(int(toY)-int(fromY)))
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
@@ -0,0 +1,82 @@
+package zoneserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/zoneserver/pathfinding"
+)
+
+func TestMoveBudget(t *testing.T) {
+	start := time.Now()
+	tests := []struct {
+		name     string
+		budget   float64
+		distance int
+		elapsed  time.Duration
+		want     bool
+	}{
+		{"step within what is left", moveDistanceTolerance, moveDistanceTolerance, 0, true},
+		{"step beyond what is left", moveDistanceTolerance, moveDistanceTolerance + 1, 0, false},
+		{"steps a few ms apart run out of budget", 0, 1, 5 * time.Millisecond, false},
+		{"one second of walking", 0, maxMoveCellsPerSecond, time.Second, true},
+		{"faster than walking speed", 0, maxMoveCellsPerSecond + 1, time.Second, false},
+		{"standing still saves up no more than the cap", 0, maxMoveBudget + 1, time.Minute, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movement := Movement{IsMoving: true, Budget: tt.budget, BudgetAt: start}
+			if got := float64(tt.distance) <= moveBudget(movement, start.Add(tt.elapsed)); got != tt.want {
+				t.Errorf("step of %d allowed = %v, want %v", tt.distance, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTakeMoveStepSpendsBudget(t *testing.T) {
+	start := time.Now()
+	movement := Movement{IsMoving: true, Budget: maxMoveBudget, BudgetAt: start}
+	takeMoveStep(&movement, maxMoveBudget-1, 3, start)
+	if movement.PathIndex != 3 {
+		t.Fatalf("PathIndex = %d, want 3", movement.PathIndex)
+	}
+
+	if budget := moveBudget(movement, start); budget != 1 {
+		t.Fatalf("budget after the step = %v, want 1", budget)
+	}
+
+	startMove(&movement, Location{}, nil, 20, 20)
+	if budget := moveBudget(movement, start); budget != 1 {
+		t.Fatalf("restarting a move changed the budget to %v", budget)
+	}
+}
+
+func TestFollowMovePath(t *testing.T) {
+	path := make([]pathfinding.Point, 0, 10)
+	for x := byte(11); x <= 20; x++ {
+		path = append(path, pathfinding.Point{X: x, Y: 10})
+	}
+
+	tests := []struct {
+		name      string
+		pathIndex int
+		x, y      byte
+		wantIndex int
+		wantOk    bool
+	}{
+		{"on the path", 0, 13, 10, 0, true},
+		{"next to the path", 0, 14, 12, 1, true},
+		{"off the path", 0, 14, 14, 0, false},
+		{"back along the path", 5, 12, 10, 0, false},
+		{"past the target", 9, 25, 10, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movement := Movement{IsMoving: true, Path: path, PathIndex: tt.pathIndex}
+			index, ok := followMovePath(movement, tt.x, tt.y)
+			if ok != tt.wantOk || (ok && index != tt.wantIndex) {
+				t.Errorf("followMovePath() = %d, %v, want %d, %v", index, ok, tt.wantIndex, tt.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package zoneserver
+
+import (
+	"context"
+	"math"
+	"slices"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/data"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages"
+)
+
+const merchantRange = 6
+
+func (z *Zone) handleBuyItem(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SBuyItem(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read buy item",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	fail := func() {
+		_ = player.Send(messages.NewMsgS2CBuyItem(player.PcId, msg.NpcId, 0, 0, 0, 0, player.Woonz, itemResultFailure).GetBytes())
+	}
+	shop := z.getMerchantShop(player, msg.NpcId)
+	if shop == nil || int(msg.ItemIndex) >= len(shop.Items) {
+		fail()
+		return
+	}
+
+	shopItem := shop.Items[msg.ItemIndex]
+	itemData, err := z.zoneManager.GetItemData(shopItem.ItemCode)
+	if err != nil || player.Woonz < itemData.NPCPrice {
+		fail()
+		return
+	}
+
+	if _, ok := freeInventorySlot(player.Inventory); !ok {
+		fail()
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), itemSerialTimeout)
+		defer cancel()
+		serial, err := z.zoneManager.serialNumberGenerator.GetNextSerial(ctx)
+		posted := z.Post(func() {
+			if err != nil {
+				z.logger.Error(
+					"Failed to get item serial for purchase",
+					shared.Field{Key: "error", Value: err},
+					shared.Field{Key: "mapId", Value: z.mapId},
+					shared.Field{Key: "pcId", Value: player.PcId},
+				)
+				fail()
+				return
+			}
+
+			z.finishBuyItem(player, msg.NpcId, shopItem, itemData, serial)
+		})
+		if !posted {
+			z.logger.Error(
+				"Failed to post item purchase",
+				shared.Field{Key: "mapId", Value: z.mapId},
+				shared.Field{Key: "pcId", Value: player.PcId},
+			)
+		}
+	}()
+}
+
+// finishBuyItem completes a purchase once the new item has its serial. The
+// checks are made again since the player may have changed in the meantime.
+func (z *Zone) finishBuyItem(player *Player, npcId uint32, shopItem data.ShopItem, itemData *data.Item, serial uint32) {
+	if player.Zone != z || player.State != PlayerStateInGame {
+		return
+	}
+
+	slot, ok := freeInventorySlot(player.Inventory)
+	if !ok || player.Woonz < itemData.NPCPrice {
+		_ = player.Send(messages.NewMsgS2CBuyItem(player.PcId, npcId, 0, 0, 0, 0, player.Woonz, itemResultFailure).GetBytes())
+		return
+	}
+
+	player.Woonz -= itemData.NPCPrice
+	player.Inventory = append(player.Inventory, InventoryItem{
+		ItemCode:       shopItem.ItemCode,
+		ItemOption:     shopItem.ItemOption,
+		ItemUniqueCode: serial,
+		Slot:           slot,
+	})
+	player.MarkDirty()
+	z.savePlayer(player, nil)
+	_ = player.Send(messages.NewMsgS2CBuyItem(
+		player.PcId,
+		npcId,
+		shopItem.ItemCode,
+		shopItem.ItemOption,
+		serial,
+		slot,
+		player.Woonz,
+		itemResultSuccess,
+	).GetBytes())
+}
+
+func (z *Zone) handleSellItem(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SSellItem(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read sell item",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	index := findInventorySlot(player.Inventory, msg.Slot)
+	shop := z.getMerchantShop(player, msg.NpcId)
+	if index < 0 || shop == nil || shop.SellPercent == 0 {
+		_ = player.Send(messages.NewMsgS2CSellItem(player.PcId, msg.NpcId, msg.Slot, player.Woonz, itemResultFailure).GetBytes())
+		return
+	}
+
+	itemData, err := z.zoneManager.GetItemData(player.Inventory[index].ItemCode)
+	if err != nil {
+		_ = player.Send(messages.NewMsgS2CSellItem(player.PcId, msg.NpcId, msg.Slot, player.Woonz, itemResultFailure).GetBytes())
+		return
+	}
+
+	price := shop.SellPrice(itemData.NPCPrice)
+	if player.Woonz > math.MaxUint32-price {
+		_ = player.Send(messages.NewMsgS2CSellItem(player.PcId, msg.NpcId, msg.Slot, player.Woonz, itemResultFailure).GetBytes())
+		return
+	}
+
+	player.Inventory = slices.Delete(player.Inventory, index, index+1)
+	player.Woonz += price
+	player.MarkDirty()
+	z.savePlayer(player, nil)
+	_ = player.Send(messages.NewMsgS2CSellItem(player.PcId, msg.NpcId, msg.Slot, player.Woonz, itemResultSuccess).GetBytes())
+}
+
+// getMerchantShop returns the shop of the merchant NPC if the player is close
+// enough to trade with it.
+func (z *Zone) getMerchantShop(player *Player, npcId uint32) *data.ShopData {
+	npc, exists := z.npcs[npcId]
+	if !exists || npc.IsMonster() ||
+		cellDistance(player.Location.X, player.Location.Y, npc.Location.X, npc.Location.Y) > merchantRange {
+		return nil
+	}
+
+	shop, exists := z.zoneManager.GetShop(npc.Spawn.Id)
+	if !exists {
+		return nil
+	}
+
+	return shop
+}
@@ -0,0 +1,305 @@
+package zoneserver
+
+import (
+	"slices"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/constants"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/data"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages"
+)
+
+const (
+	// warpGateRange is how far from where the warps back to this map land a
+	// player can be and still use the gate.
+	warpGateRange = 8
+	warpNPCRange  = merchantRange
+)
+
+func (z *Zone) handleWarp(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SWarp(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read warp",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	if int(msg.WarpIndex) >= len(z.mapData.WarpData) {
+		z.logger.Debug(
+			"Invalid warp index",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "pcId", Value: player.PcId},
+			shared.Field{Key: "warpIndex", Value: msg.WarpIndex},
+		)
+		return
+	}
+
+	if !z.isNearWarpGate(player, int(msg.WarpIndex)) {
+		z.logger.Debug(
+			"Player is not near the warp gate",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "pcId", Value: player.PcId},
+			shared.Field{Key: "warpIndex", Value: msg.WarpIndex},
+		)
+		return
+	}
+
+	z.warpPlayer(player, z.mapData.WarpData[msg.WarpIndex])
+}
+
+func (z *Zone) handleAskWarpZ2B(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SAskWarpZ2B(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read ask warp z2b",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	if !z.isNearWarpNPC(player) {
+		return
+	}
+
+	z.warpPlayerToMap(player, msg.MapId)
+}
+
+func (z *Zone) handleAskWarpB2Z(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SAskWarpB2Z(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read ask warp b2z",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	if !z.isNearWarpNPC(player) {
+		return
+	}
+
+	z.warpPlayerToMap(player, msg.MapId)
+}
+
+func (z *Zone) handleZoneChange(packet []byte) {
+	msg, err := messages.ReadMsgM2SZoneChange(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read zone change",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "mapId", Value: z.mapId},
+		)
+		return
+	}
+
+	player, exists := z.players.Get(msg.PcId)
+	if !exists || player.Zone != z || player.State != PlayerStateChangingZone {
+		return
+	}
+
+	if msg.Result != constants.ZoneChangeResultSuccess {
+		z.logger.Error(
+			"Main server rejected zone change",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "pcId", Value: player.PcId},
+			shared.Field{Key: "targetMapId", Value: msg.MapId},
+		)
+		z.cancelZoneChange(player)
+		return
+	}
+
+	if msg.ZoneServerId != z.cfg.ServerId {
+		zoneChange := messages.NewMsgS2GZoneChange(player.PcId, msg.ZoneServerId)
+		if err := player.Send(zoneChange.GetBytes()); err != nil {
+			z.logger.Error(
+				"Failed to send zone change to gate server",
+				shared.Field{Key: "error", Value: err},
+				shared.Field{Key: "mapId", Value: z.mapId},
+				shared.Field{Key: "pcId", Value: player.PcId},
+			)
+		}
+
+		z.players.Remove(player.PcId)
+		z.logger.Info(
+			"Player moved to another zone server",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "pcId", Value: player.PcId},
+			shared.Field{Key: "targetMapId", Value: msg.MapId},
+			shared.Field{Key: "targetServerId", Value: msg.ZoneServerId},
+		)
+		return
+	}
+
+	target := z.zoneManager.GetZone(msg.MapId)
+	if target == nil {
+		z.logger.Error(
+			"Zone not found for zone change",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "pcId", Value: player.PcId},
+			shared.Field{Key: "targetMapId", Value: msg.MapId},
+		)
+		z.cancelZoneChange(player)
+		return
+	}
+
+	player.Zone = target
+	player.State = PlayerStateWorldLoginSuccess
+	if !target.EnqueuePlayerLogin(player.PcId) {
+		z.logger.Error(
+			"Failed to enqueue player login",
+			shared.Field{Key: "pcId", Value: player.PcId},
+			shared.Field{Key: "mapId", Value: msg.MapId},
+		)
+		player.Zone = z
+		player.State = PlayerStateChangingZone
+		z.cancelZoneChange(player)
+	}
+}
+
+// isNearWarpGate reports whether the player stands by the gate of the warp.
+// The map data only gives where a warp lands, so the gate is found from the
+// other side: the warps of the destination map that lead back here land
+// players in front of this map's gate.
+func (z *Zone) isNearWarpGate(player *Player, warpIndex int) bool {
+	warp := z.mapData.WarpData[warpIndex]
+	destination, err := z.zoneManager.GetMapData(warp.MapId)
+	if err != nil {
+		z.logger.Error(
+			"Failed to load warp destination map data",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "targetMapId", Value: warp.MapId},
+		)
+		return false
+	}
+
+	for i, back := range destination.WarpData {
+		if back.MapId != z.mapId || (warp.MapId == z.mapId && i == warpIndex) {
+			continue
+		}
+
+		if cellDistance(player.Location.X, player.Location.Y, back.X, back.Y) <= warpGateRange {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isNearWarpNPC reports whether the player is close enough to an NPC to be
+// sent elsewhere by it.
+func (z *Zone) isNearWarpNPC(player *Player) bool {
+	near := false
+	z.grid.ForEachNPCInRange(player.Location.X, player.Location.Y, func(npc *NPC) bool {
+		near = !npc.IsMonster() && !npc.IsDead &&
+			cellDistance(player.Location.X, player.Location.Y, npc.Location.X, npc.Location.Y) <= warpNPCRange
+		return !near
+	})
+
+	return near
+}
+
+func (z *Zone) warpPlayerToMap(player *Player, mapId uint16) {
+	for _, warp := range z.mapData.WarpData {
+		if warp.MapId == mapId {
+			z.warpPlayer(player, warp)
+			return
+		}
+	}
+
+	z.logger.Debug(
+		"No warp to map",
+		shared.Field{Key: "mapId", Value: z.mapId},
+		shared.Field{Key: "pcId", Value: player.PcId},
+		shared.Field{Key: "targetMapId", Value: mapId},
+	)
+}
+
+// warpPlayer moves the player to the warp destination. A warp within the map
+// is done in place. For any other map the player leaves the world, is saved
+// at the destination and the main server decides which zone server takes the
+// player next.
+func (z *Zone) warpPlayer(player *Player, warp data.WarpData) {
+	if player.IsDead() {
+		return
+	}
+
+	player.Movement.IsMoving = false
+	if warp.MapId == z.mapId {
+		z.removePlayerFromWorld(player)
+		player.Location.X = warp.X
+		player.Location.Y = warp.Y
+		z.moveMercenaryToPlayer(player)
+		player.MarkDirty()
+		_ = player.Send(z.newWorldLoginMsg(player).GetBytes())
+		z.addPlayerToWorld(player)
+		return
+	}
+
+	z.closeStorage(player)
+	z.removePlayerFromWorld(player)
+	z.currentPlayers = slices.DeleteFunc(z.currentPlayers, func(pcId uint32) bool {
+		return pcId == player.PcId
+	})
+	player.State = PlayerStateChangingZone
+	player.warpOrigin = player.Location
+	player.Location = Location{MapId: warp.MapId, X: warp.X, Y: warp.Y}
+	z.moveMercenaryToPlayer(player)
+	z.savePlayer(player, func(err error) {
+		if player.State != PlayerStateChangingZone {
+			return
+		}
+
+		if err != nil {
+			z.cancelZoneChange(player)
+			return
+		}
+
+		msg := messages.NewMsgS2MZoneChange(player.PcId, warp.MapId, player.GateServerSession.agentId)
+		if err := z.zoneManager.SendToMainServer(msg.GetBytes()); err != nil {
+			z.logger.Error(
+				"Failed to send zone change to main server",
+				shared.Field{Key: "error", Value: err},
+				shared.Field{Key: "mapId", Value: z.mapId},
+				shared.Field{Key: "pcId", Value: player.PcId},
+			)
+			z.cancelZoneChange(player)
+		}
+	})
+}
+
+// cancelZoneChange puts the player back where the warp started.
+func (z *Zone) cancelZoneChange(player *Player) {
+	player.Location = player.warpOrigin
+	player.State = PlayerStateInGame
+	player.MarkDirty()
+	z.moveMercenaryToPlayer(player)
+	z.currentPlayers = append(z.currentPlayers, player.PcId)
+	z.addPlayerToWorld(player)
+	_ = player.Send(messages.NewMsgS2CFixMove(player.PcId, player.PcId, player.Location.Cell()).GetBytes())
+}
+
+// placeMercenary gives the player's mercenary an id in this zone and puts it
+// next to the player.
+func (z *Zone) placeMercenary(player *Player) {
+	if player.Mercenary == nil {
+		return
+	}
+
+	player.Mercenary.Id = z.npcUidGenerator.Uid()
+	z.moveMercenaryToPlayer(player)
+}
+
+func (z *Zone) moveMercenaryToPlayer(player *Player) {
+	if player.Mercenary == nil {
+		return
+	}
+
+	player.Mercenary.Movement.IsMoving = false
+	player.Mercenary.Location = player.Location
+}
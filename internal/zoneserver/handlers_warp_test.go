@@ -0,0 +1,43 @@
+package zoneserver
+
+import (
+	"testing"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/data"
+)
+
+func TestIsNearWarpGate(t *testing.T) {
+	zoneManager := &ZoneManager{mapsData: shared.NewSafeMap[uint16, *data.MapData]()}
+	town := &data.MapData{Id: 1, WarpData: []data.WarpData{
+		{MapId: 2, X: 10, Y: 10},
+		{MapId: 1, X: 200, Y: 200},
+		{MapId: 1, X: 100, Y: 100},
+	}}
+	field := &data.MapData{Id: 2, WarpData: []data.WarpData{{MapId: 1, X: 50, Y: 50}}}
+	zoneManager.mapsData.Set(1, town)
+	zoneManager.mapsData.Set(2, field)
+	z := &Zone{mapId: 1, mapData: town, zoneManager: zoneManager}
+
+	tests := []struct {
+		name      string
+		warpIndex int
+		x, y      byte
+		want      bool
+	}{
+		{"at the gate to another map", 0, 50, 50, true},
+		{"within range of the gate", 0, 50 + warpGateRange, 50, true},
+		{"out of range of the gate", 0, 50 + warpGateRange + 1, 50, false},
+		{"far away from every gate", 0, 150, 150, false},
+		{"at the other gate of a warp within the map", 1, 100, 100, true},
+		{"at the landing spot of the warp itself", 1, 200, 200, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			player := &Player{Location: Location{MapId: 1, X: tt.x, Y: tt.y}}
+			if got := z.isNearWarpGate(player, tt.warpIndex); got != tt.want {
+				t.Errorf("isNearWarpGate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -29,6 +29,7 @@ type MainServerClient struct {
 	reconnectDelay  time.Duration
 	isConnected     bool
 	players         *Players
+	gateSessions    *GateSessions
 	zoneManager     *ZoneManager
 	db              db.DBService
 }
@@ -38,17 +39,19 @@ func NewMainServerClient(
 	addr string,
 	logger shared.Logger,
 	players *Players,
+	gateSessions *GateSessions,
 	zoneManager *ZoneManager,
 	db db.DBService,
 ) *MainServerClient {
 	return &MainServerClient{
-		serverId:    serverId,
-		addr:        addr,
-		logger:      logger,
-		players:     players,
-		isConnected: false,
-		zoneManager: zoneManager,
-		db:          db,
+		serverId:     serverId,
+		addr:         addr,
+		logger:       logger,
+		players:      players,
+		gateSessions: gateSessions,
+		isConnected:  false,
+		zoneManager:  zoneManager,
+		db:           db,
 	}
 }
 
@@ -199,6 +202,28 @@ func (c *MainServerClient) processPacket(packet []byte) {
 		}
 
 		characterName := utils.ReadStringFromBytes(msg.CharacterName[:])
+		zone := c.zoneManager.GetZone(msg.MapId)
+		if zone == nil {
+			c.logger.Error(
+				"Zone not found for world login",
+				shared.Field{Key: "mapId", Value: msg.MapId},
+				shared.Field{Key: "characterName", Value: characterName},
+				shared.Field{Key: "pcId", Value: pcId},
+			)
+			return
+		}
+
+		gateServerSession, exists := c.gateSessions.Get(msg.GateServerId)
+		if !exists {
+			c.logger.Error(
+				"Gate server session not found for world login",
+				shared.Field{Key: "gateServerId", Value: msg.GateServerId},
+				shared.Field{Key: "characterName", Value: characterName},
+				shared.Field{Key: "pcId", Value: pcId},
+			)
+			return
+		}
+
 		characterData, err := c.db.GetCharacter(pcId, characterName)
 		if err != nil {
 			c.logger.Error(
@@ -210,84 +235,44 @@ func (c *MainServerClient) processPacket(packet []byte) {
 			return
 		}
 
+		clanMembership, err := c.db.GetClanMembership(characterData.ID)
+		if err != nil {
+			c.logger.Error(
+				"Failed to get clan membership",
+				shared.Field{Key: "error", Value: err},
+				shared.Field{Key: "characterName", Value: characterName},
+				shared.Field{Key: "pcId", Value: pcId},
+			)
+			return
+		}
+
 		player := NewPlayer(
 			pcId,
 			characterData.Account,
 			characterName,
-			nil,
+			gateServerSession,
 			c.logger,
-			c.zoneManager.GetZone(msg.MapId),
+			zone,
 		)
-		player.Class = characterData.Class
-		player.Level = characterData.Level
-		player.Lore = characterData.Data.Lore
-		player.Woonz = characterData.Data.Parole
-		player.SocialInfo = SocialInfo{
-			Nation: characterData.Data.SocialInfo.Nation,
-		}
-		player.Location = Location{
-			MapId: characterData.Data.Location.MapCode,
-			X:     characterData.Data.Location.Position.X,
-			Y:     characterData.Data.Location.Position.Y,
-		}
-		player.Stats = Stats{
-			RemainingPoints: characterData.Data.Stats.RemainingPoints,
-			Strength:        characterData.Data.Stats.Strength,
-			Intelligence:    characterData.Data.Stats.Intelligence,
-			Dexterity:       characterData.Data.Stats.Dexterity,
-			Vitality:        characterData.Data.Stats.Vitality,
-			Mana:            characterData.Data.Stats.Mana,
-			HPCapacity:      characterData.Data.Stats.HPCapacity,
-			MPCapacity:      characterData.Data.Stats.MPCapacity,
-			HP:              characterData.Data.Stats.HP,
-			MP:              characterData.Data.Stats.MP,
-		}
-		player.Wear = make([]WearItem, len(characterData.Data.Wear))
-		for i, wearItem := range characterData.Data.Wear {
-			player.Wear[i] = WearItem{
-				ItemCode:       wearItem.ItemCode,
-				ItemOption:     wearItem.ItemOption,
-				ItemUniqueCode: wearItem.ItemUniqueCode,
-			}
-		}
-		player.Inventory = make([]InventoryItem, len(characterData.Data.Inventory))
-		for i, invItem := range characterData.Data.Inventory {
-			player.Inventory[i] = InventoryItem{
-				ItemCode:       invItem.ItemCode,
-				ItemOption:     invItem.ItemOption,
-				ItemUniqueCode: invItem.ItemUniqueCode,
-				Slot:           invItem.Slot,
-			}
-		}
-		player.Skills = make([]Skill, len(characterData.Data.Skills))
-		for i, skill := range characterData.Data.Skills {
-			player.Skills[i] = Skill{
-				Id:    skill.SkillID,
-				Level: skill.Level,
-			}
-		}
-		player.ActivePet = Pet{
-			PetCode:       characterData.Data.ActivePet.PetCode,
-			PetHP:         characterData.Data.ActivePet.PetHP,
-			PetOption:     characterData.Data.ActivePet.PetOption,
-			PetUniqueCode: characterData.Data.ActivePet.PetUniqueCode,
-		}
-		player.PetInventory = make([]PetInventory, len(characterData.Data.PetInventory))
-		for i, petInv := range characterData.Data.PetInventory {
-			player.PetInventory[i] = PetInventory{
-				Pet: Pet{
-					PetCode:       petInv.PetCode,
-					PetHP:         petInv.PetHP,
-					PetOption:     petInv.PetOption,
-					PetUniqueCode: petInv.PetUniqueCode,
-				},
-				Slot: petInv.Slot,
-			}
-		}
+		loadCharacter(player, characterData, c.zoneManager)
+		applyClanMembership(player, clanMembership)
 
-		player.Zone.EnqueuePlayerLogin(pcId)
 		player.State = PlayerStateWorldLoginSuccess
 		c.players.Add(player)
+		if !player.Zone.EnqueuePlayerLogin(pcId) {
+			c.players.Remove(pcId)
+			c.logger.Error(
+				"Failed to enqueue player login",
+				shared.Field{Key: "pcId", Value: pcId},
+				shared.Field{Key: "mapId", Value: msg.MapId},
+			)
+		}
+
+		return
+	}
+
+	if proto == protocol.M2SNationChat {
+		c.handleNationChat(packet)
 		return
 	}
 
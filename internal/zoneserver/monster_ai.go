@@ -0,0 +1,360 @@
+package zoneserver
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/data"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages"
+	"github.com/project-agonyl/open-agonyl-servers/internal/zoneserver/pathfinding"
+)
+
+type npcAIState int
+
+const (
+	npcAIStateIdle npcAIState = iota
+	npcAIStateRoam
+	npcAIStateAggro
+	npcAIStateChase
+	npcAIStateAttack
+	npcAIStateReturn
+	npcAIStateDead
+)
+
+const (
+	monsterAggroRange       = 6
+	monsterLeashRange       = 20
+	monsterRoamRange        = 4
+	monsterRoamChance       = 0.3
+	monsterIdleMin          = 3 * time.Second
+	monsterIdleMax          = 8 * time.Second
+	monsterWalkInterval     = 500 * time.Millisecond
+	monsterChaseInterval    = 300 * time.Millisecond
+	monsterMinAttackSpeedMs = 500
+)
+
+type npcAI struct {
+	state        npcAIState
+	targetPcId   uint32
+	path         []pathfinding.Point
+	nextActionAt time.Time
+	nextAttackAt time.Time
+}
+
+// isAggressive reports whether the monster attacks players on sight.
+// Monsters without an attack type never fight, and monsters without a
+// target selection only fight back once provoked.
+func (n *NPC) isAggressive() bool {
+	return n.canFight() && n.Data.TargetSelectionInfo != 0
+}
+
+func (n *NPC) canFight() bool {
+	return n.Data.AttackTypeInfo != 0
+}
+
+func (z *Zone) updateMonsters(now time.Time) {
+	for _, npc := range z.npcs {
+		if npc.IsDead || !npc.IsMonster() || now.Before(npc.ai.nextActionAt) {
+			continue
+		}
+
+		switch npc.ai.state {
+		case npcAIStateIdle:
+			z.monsterIdle(npc, now)
+		case npcAIStateRoam:
+			z.monsterRoam(npc, now)
+		case npcAIStateAggro:
+			z.monsterAggro(npc, now)
+		case npcAIStateChase:
+			z.monsterChase(npc, now)
+		case npcAIStateAttack:
+			z.monsterAttack(npc, now)
+		case npcAIStateReturn:
+			z.monsterReturn(npc, now)
+		}
+	}
+}
+
+// provokeMonster makes a monster that is able to fight target the attacker,
+// even if it is not aggressive on its own.
+func (z *Zone) provokeMonster(npc *NPC, player *Player) {
+	if npc.IsDead || !npc.IsMonster() || !npc.canFight() {
+		return
+	}
+
+	switch npc.ai.state {
+	case npcAIStateIdle, npcAIStateRoam:
+		npc.ai.targetPcId = player.PcId
+		npc.ai.state = npcAIStateAggro
+		npc.ai.nextActionAt = time.Time{}
+	}
+}
+
+func (z *Zone) monsterIdle(npc *NPC, now time.Time) {
+	if z.monsterScanForTarget(npc) {
+		return
+	}
+
+	if rand.Float64() >= monsterRoamChance {
+		npc.ai.nextActionAt = now.Add(randomDuration(monsterIdleMin, monsterIdleMax))
+		return
+	}
+
+	x := min(max(int(npc.Spawn.X)+rand.IntN(2*monsterRoamRange+1)-monsterRoamRange, 0), 0xFF)
+	y := min(max(int(npc.Spawn.Y)+rand.IntN(2*monsterRoamRange+1)-monsterRoamRange, 0), 0xFF)
+	path, err := z.findNPCPath(npc, byte(x), byte(y))
+	if err != nil || len(path) == 0 {
+		npc.ai.nextActionAt = now.Add(randomDuration(monsterIdleMin, monsterIdleMax))
+		return
+	}
+
+	npc.ai.path = path
+	npc.ai.state = npcAIStateRoam
+}
+
+func (z *Zone) monsterRoam(npc *NPC, now time.Time) {
+	if z.monsterScanForTarget(npc) {
+		return
+	}
+
+	if !z.stepNPC(npc) {
+		npc.ai.state = npcAIStateIdle
+		npc.ai.nextActionAt = now.Add(randomDuration(monsterIdleMin, monsterIdleMax))
+		return
+	}
+
+	npc.ai.nextActionAt = now.Add(monsterWalkInterval)
+}
+
+func (z *Zone) monsterAggro(npc *NPC, now time.Time) {
+	target := z.getMonsterTarget(npc)
+	if target == nil {
+		z.startMonsterReturn(npc)
+		return
+	}
+
+	npc.ai.path = nil
+	if _, ok := z.pickMonsterAttack(npc, target); ok {
+		npc.ai.state = npcAIStateAttack
+	} else {
+		npc.ai.state = npcAIStateChase
+	}
+}
+
+func (z *Zone) monsterChase(npc *NPC, now time.Time) {
+	target := z.getMonsterTarget(npc)
+	if target == nil || z.isMonsterLeashed(npc) {
+		z.startMonsterReturn(npc)
+		return
+	}
+
+	if _, ok := z.pickMonsterAttack(npc, target); ok {
+		npc.ai.state = npcAIStateAttack
+		npc.ai.path = nil
+		return
+	}
+
+	goal := pathfinding.Point{X: target.Location.X, Y: target.Location.Y}
+	if len(npc.ai.path) == 0 || npc.ai.path[len(npc.ai.path)-1] != goal {
+		path, err := z.findNPCPath(npc, goal.X, goal.Y)
+		if err != nil || len(path) == 0 {
+			z.startMonsterReturn(npc)
+			return
+		}
+
+		npc.ai.path = path
+	}
+
+	z.stepNPC(npc)
+	npc.ai.nextActionAt = now.Add(monsterChaseInterval)
+}
+
+func (z *Zone) monsterAttack(npc *NPC, now time.Time) {
+	target := z.getMonsterTarget(npc)
+	if target == nil {
+		z.startMonsterReturn(npc)
+		return
+	}
+
+	attack, ok := z.pickMonsterAttack(npc, target)
+	if !ok {
+		npc.ai.state = npcAIStateChase
+		return
+	}
+
+	if now.Before(npc.ai.nextAttackAt) {
+		npc.ai.nextActionAt = npc.ai.nextAttackAt
+		return
+	}
+
+	z.damagePlayer(target, npc.Id, monsterDamage(attack, target))
+	if attack.Area > 0 {
+		z.grid.ForEachPlayerInRange(target.Location.X, target.Location.Y, func(player *Player) bool {
+			if player.PcId != target.PcId && !player.IsDead() &&
+				cellDistance(target.Location.X, target.Location.Y, player.Location.X, player.Location.Y) <= int(attack.Area) {
+				z.damagePlayer(player, npc.Id, monsterDamage(attack, player))
+			}
+
+			return true
+		})
+	}
+
+	npc.ai.nextAttackAt = now.Add(monsterAttackDelay(npc.Data))
+	npc.ai.nextActionAt = npc.ai.nextAttackAt
+}
+
+func (z *Zone) monsterReturn(npc *NPC, now time.Time) {
+	if z.stepNPC(npc) {
+		npc.ai.nextActionAt = now.Add(monsterChaseInterval)
+		return
+	}
+
+	if npc.Location.X != npc.Spawn.X || npc.Location.Y != npc.Spawn.Y {
+		z.moveNPCInWorld(npc, npc.Spawn.X, npc.Spawn.Y)
+	}
+
+	npc.HP = npc.Data.HP
+	npc.ai.state = npcAIStateIdle
+	npc.ai.nextActionAt = now.Add(randomDuration(monsterIdleMin, monsterIdleMax))
+}
+
+func (z *Zone) startMonsterReturn(npc *NPC) {
+	npc.ai.targetPcId = 0
+	npc.ai.state = npcAIStateReturn
+	npc.ai.path, _ = z.findNPCPath(npc, npc.Spawn.X, npc.Spawn.Y)
+}
+
+// monsterScanForTarget makes an aggressive monster pick the closest player
+// within its aggro range. It reports whether a target was found.
+func (z *Zone) monsterScanForTarget(npc *NPC) bool {
+	if !npc.isAggressive() {
+		return false
+	}
+
+	var target *Player
+	targetDistance := monsterAggroRange + 1
+	z.grid.ForEachPlayerInRange(npc.Location.X, npc.Location.Y, func(player *Player) bool {
+		distance := cellDistance(npc.Location.X, npc.Location.Y, player.Location.X, player.Location.Y)
+		if !player.IsDead() && distance < targetDistance {
+			target = player
+			targetDistance = distance
+		}
+
+		return true
+	})
+
+	if target == nil {
+		return false
+	}
+
+	npc.ai.targetPcId = target.PcId
+	npc.ai.state = npcAIStateAggro
+	return true
+}
+
+func (z *Zone) getMonsterTarget(npc *NPC) *Player {
+	player, exists := z.players.Get(npc.ai.targetPcId)
+	if !exists || player.Zone != z || player.State != PlayerStateInGame || player.IsDead() {
+		return nil
+	}
+
+	return player
+}
+
+func (z *Zone) isMonsterLeashed(npc *NPC) bool {
+	return cellDistance(npc.Location.X, npc.Location.Y, npc.Spawn.X, npc.Spawn.Y) > monsterLeashRange
+}
+
+// pickMonsterAttack picks one of the monster attacks that can reach the
+// target, preferring the strongest one.
+func (z *Zone) pickMonsterAttack(npc *NPC, target *Player) (data.NPCAttack, bool) {
+	distance := cellDistance(npc.Location.X, npc.Location.Y, target.Location.X, target.Location.Y)
+	var picked data.NPCAttack
+	found := false
+	for _, attack := range npc.Data.Attacks {
+		if attack.Damage == 0 || int(max(attack.Range, 1)) < distance {
+			continue
+		}
+
+		if found && attack.Damage+attack.AdditionalDamage <= picked.Damage+picked.AdditionalDamage {
+			continue
+		}
+
+		picked = attack
+		found = true
+	}
+
+	if found && distance > 1 && !z.pathfinder.HasLineOfSight(
+		pathfinding.Point{X: npc.Location.X, Y: npc.Location.Y},
+		pathfinding.Point{X: target.Location.X, Y: target.Location.Y},
+	) {
+		return picked, false
+	}
+
+	return picked, found
+}
+
+func (z *Zone) findNPCPath(npc *NPC, x byte, y byte) ([]pathfinding.Point, error) {
+	return z.pathfinder.FindPath(pathfinding.Point{X: npc.Location.X, Y: npc.Location.Y}, pathfinding.Point{X: x, Y: y})
+}
+
+// stepNPC moves the NPC one cell along its current path. It reports whether
+// a step was taken.
+func (z *Zone) stepNPC(npc *NPC) bool {
+	if len(npc.ai.path) == 0 {
+		return false
+	}
+
+	next := npc.ai.path[0]
+	npc.ai.path = npc.ai.path[1:]
+	z.moveNPCInWorld(npc, next.X, next.Y)
+	return true
+}
+
+// moveNPCInWorld moves the NPC to the given cell, hides it from the players
+// that lost sight of it and shows it to the players that came into view.
+func (z *Zone) moveNPCInWorld(npc *NPC, x byte, y byte) {
+	fromCell := npc.Location.Cell()
+	left, entered := z.grid.MoveNPC(npc, x, y)
+	if len(left) > 0 {
+		disappear := newNpcDisappearMsg(npc).GetBytes()
+		for _, sector := range left {
+			for _, player := range sector.players {
+				z.relay(player, disappear)
+			}
+		}
+	}
+
+	for _, sector := range entered {
+		for _, player := range sector.players {
+			z.relay(player, z.newNpcInitializeMsg(npc).GetBytes())
+		}
+	}
+
+	seeMove := messages.NewMsgS2CSeeMove(npc.Id, npc.Id, fromCell, npc.Location.Cell()).GetBytes()
+	z.BroadcastNearby(x, y, seeMove, 0)
+}
+
+func monsterDamage(attack data.NPCAttack, target *Player) uint32 {
+	damage := int(attack.Damage)
+	if attack.AdditionalDamage > 0 {
+		damage += rand.IntN(int(attack.AdditionalDamage) + 1)
+	}
+
+	return uint32(max(damage-int(target.Stats.Defense), 1))
+}
+
+// monsterAttackDelay picks the time until the next attack in the range
+// given by the NPC attack speeds, which are in milliseconds.
+func monsterAttackDelay(npcData *data.NPCData) time.Duration {
+	low, high := int(npcData.AttackSpeedLow), int(npcData.AttackSpeedHigh)
+	if high < low || low == 0 {
+		low, high = int(npcData.AttackSpeed), int(npcData.AttackSpeed)
+	}
+
+	return time.Duration(max(low+rand.IntN(high-low+1), monsterMinAttackSpeedMs)) * time.Millisecond
+}
+
+func randomDuration(low time.Duration, high time.Duration) time.Duration {
+	return low + rand.N(high-low)
+}
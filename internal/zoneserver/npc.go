@@ -0,0 +1,121 @@
+package zoneserver
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/data"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages"
+)
+
+// npcUidStart keeps NPC ids clear of the pcIds handed out by the gate server.
+const npcUidStart uint32 = 0x40000000
+
+type NPC struct {
+	Id          uint32
+	Data        *data.NPCData
+	Spawn       data.NPCSpawnData
+	Location    Location
+	Orientation byte
+	HP          uint32
+	IsDead      bool
+	ai          npcAI
+}
+
+func (n *NPC) IsMonster() bool {
+	return n.Spawn.IsMonster()
+}
+
+func (z *Zone) loadNPCs() {
+	spawnFilePath := z.cfg.ZoneDataSpawnPath + "/" + strconv.Itoa(int(z.mapId)) + ".n_ndt"
+	spawns, err := data.LoadNPCSpawnData(spawnFilePath)
+	if err != nil {
+		z.logger.Warn(
+			"Could not load spawn data, zone will have no NPCs",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "path", Value: spawnFilePath},
+			shared.Field{Key: "error", Value: err},
+		)
+		return
+	}
+
+	for _, spawn := range spawns {
+		npcData, err := z.zoneManager.GetNPCData(spawn.Id)
+		if err != nil {
+			z.logger.Warn(
+				"Could not load NPC data for spawn",
+				shared.Field{Key: "mapId", Value: z.mapId},
+				shared.Field{Key: "npcCode", Value: spawn.Id},
+				shared.Field{Key: "error", Value: err},
+			)
+			continue
+		}
+
+		npc := &NPC{
+			Id:    z.npcUidGenerator.Uid(),
+			Data:  npcData,
+			Spawn: spawn,
+		}
+		z.npcs[npc.Id] = npc
+		z.spawnNPC(npc)
+	}
+
+	z.logger.Info(
+		"Loaded NPCs",
+		shared.Field{Key: "mapId", Value: z.mapId},
+		shared.Field{Key: "count", Value: len(z.npcs)},
+	)
+}
+
+// spawnNPC places the NPC at its spawn point with full HP and shows it to
+// the players around it.
+func (z *Zone) spawnNPC(npc *NPC) {
+	npc.Location = Location{MapId: z.mapId, X: npc.Spawn.X, Y: npc.Spawn.Y}
+	npc.Orientation = npc.Spawn.Orientation
+	npc.HP = npc.Data.HP
+	npc.IsDead = false
+	npc.ai = npcAI{state: npcAIStateIdle}
+	z.grid.AddNPC(npc)
+	z.BroadcastNearby(npc.Location.X, npc.Location.Y, z.newNpcInitializeMsg(npc).GetBytes(), 0)
+}
+
+// killNPC removes the NPC from the world, hides it from the players around
+// it and, for monsters, schedules its respawn after the NPC data respawn
+// rate.
+func (z *Zone) killNPC(npc *NPC) {
+	if npc.IsDead {
+		return
+	}
+
+	npc.IsDead = true
+	npc.HP = 0
+	npc.ai = npcAI{state: npcAIStateDead}
+	z.grid.RemoveNPC(npc)
+	z.BroadcastNearby(npc.Location.X, npc.Location.Y, newNpcDisappearMsg(npc).GetBytes(), 0)
+	if !npc.IsMonster() {
+		return
+	}
+
+	z.After(time.Duration(npc.Data.RespawnRate)*time.Second, func() {
+		z.spawnNPC(npc)
+	})
+}
+
+func (z *Zone) newNpcInitializeMsg(npc *NPC) *messages.MsgS2CNpcInitialize {
+	return messages.NewMsgS2CNpcInitialize(
+		npc.Id,
+		npc.Id,
+		npc.Data.Id,
+		npc.Location.Cell(),
+		npc.Orientation,
+		npc.HP,
+		npc.Data.HP,
+	)
+}
+
+// newNpcDisappearMsg uses the same disappear packet as players, the client
+// tells the two apart by id.
+func newNpcDisappearMsg(npc *NPC) *messages.MsgS2CPcDisappear {
+	return messages.NewMsgS2CPcDisappear(npc.Id, npc.Id)
+}
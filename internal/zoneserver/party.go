@@ -0,0 +1,297 @@
+package zoneserver
+
+import (
+	"slices"
+	"time"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages"
+	"github.com/project-agonyl/open-agonyl-servers/internal/utils"
+)
+
+const partyUpdateInterval = 2 * time.Second
+
+// Party is the zone server's copy of the party a player is in. The main
+// server owns the party and sends it whenever it changes.
+type Party struct {
+	LeaderId uint32
+	Members  []PartyMember
+}
+
+type PartyMember struct {
+	PcId     uint32
+	Name     string
+	Level    uint16
+	HP       uint16
+	MaxHp    uint16
+	Location Location
+}
+
+func (p *Party) HasMember(pcId uint32) bool {
+	return slices.ContainsFunc(p.Members, func(member PartyMember) bool {
+		return member.PcId == pcId
+	})
+}
+
+// partyUpdate is the member state last reported to the main server, so that
+// it is only sent again when it changes.
+type partyUpdate struct {
+	level    uint16
+	hp       uint16
+	maxHp    uint16
+	location Location
+}
+
+func (z *Zone) handleAskParty(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SAskParty(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read ask party",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	z.sendToMainServer(player, messages.NewMsgS2MAskParty(player.PcId, msg.TargetId, player.GateServerSession.agentId).GetBytes())
+}
+
+func (z *Zone) handleAnsParty(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SAnsParty(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read ans party",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	z.sendToMainServer(player, messages.NewMsgS2MAnsParty(player.PcId, msg.RequesterId, msg.Accept, player.GateServerSession.agentId).GetBytes())
+}
+
+func (z *Zone) handleOutParty(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SOutParty(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read out party",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	z.sendToMainServer(player, messages.NewMsgS2MOutParty(player.PcId, msg.TargetId, player.GateServerSession.agentId).GetBytes())
+}
+
+func (z *Zone) handleMainServerAskParty(packet []byte) {
+	msg, err := messages.ReadMsgM2SAskParty(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read main server ask party",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "mapId", Value: z.mapId},
+		)
+		return
+	}
+
+	player, exists := z.players.Get(msg.PcId)
+	if !exists || player.Zone != z {
+		return
+	}
+
+	requesterName := utils.ReadStringFromBytes(msg.RequesterName[:])
+	_ = player.Send(messages.NewMsgS2CAskParty(player.PcId, msg.RequesterId, requesterName).GetBytes())
+}
+
+func (z *Zone) handleMainServerAnsParty(packet []byte) {
+	msg, err := messages.ReadMsgM2SAnsParty(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read main server ans party",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "mapId", Value: z.mapId},
+		)
+		return
+	}
+
+	player, exists := z.players.Get(msg.PcId)
+	if !exists || player.Zone != z {
+		return
+	}
+
+	_ = player.Send(messages.NewMsgS2CAnsParty(player.PcId, msg.PartnerId, msg.Result).GetBytes())
+}
+
+// handlePartyInfo replaces the player's party with the one the main server
+// sent. A party without members means the player is no longer in one.
+func (z *Zone) handlePartyInfo(packet []byte) {
+	msg, err := messages.ReadMsgM2SPartyInfo(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read party info",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "mapId", Value: z.mapId},
+		)
+		return
+	}
+
+	player, exists := z.players.Get(msg.PcId)
+	if !exists || player.Zone != z {
+		return
+	}
+
+	info := messages.NewMsgS2CPartyInfo(player.PcId, msg.LeaderId)
+	info.MemberCount = msg.MemberCount
+	info.Members = msg.Members
+	if msg.MemberCount == 0 {
+		player.Party = nil
+		_ = player.Send(info.GetBytes())
+		return
+	}
+
+	party := &Party{LeaderId: msg.LeaderId}
+	for _, member := range msg.Members[:min(int(msg.MemberCount), len(msg.Members))] {
+		party.Members = append(party.Members, newPartyMember(member))
+	}
+
+	player.Party = party
+	_ = player.Send(info.GetBytes())
+}
+
+func (z *Zone) handlePartyMemberUpdate(packet []byte) {
+	msg, err := messages.ReadMsgM2SPartyMemberUpdate(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read party member update",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "mapId", Value: z.mapId},
+		)
+		return
+	}
+
+	player, exists := z.players.Get(msg.PcId)
+	if !exists || player.Zone != z || player.Party == nil {
+		return
+	}
+
+	index := slices.IndexFunc(player.Party.Members, func(member PartyMember) bool {
+		return member.PcId == msg.Member.PcId
+	})
+	if index < 0 {
+		return
+	}
+
+	player.Party.Members[index] = newPartyMember(msg.Member)
+	_ = player.Send(messages.NewMsgS2CPartyMemberUpdate(player.PcId, msg.Member).GetBytes())
+}
+
+// sendPartyUpdate reports the player's level, HP and location to the main
+// server if they changed since the last report. entered is set when the
+// player has just come into the zone, which also asks for the whole party.
+func (z *Zone) sendPartyUpdate(player *Player, entered bool) {
+	update := partyUpdate{
+		level:    player.Level,
+		hp:       player.Stats.HP,
+		maxHp:    player.Stats.MaxHp,
+		location: player.Location,
+	}
+	if !entered && update == player.lastPartyUpdate {
+		return
+	}
+
+	player.lastPartyUpdate = update
+	var enteredFlag byte
+	if entered {
+		enteredFlag = 1
+	}
+
+	z.sendToMainServer(player, messages.NewMsgS2MPartyMemberUpdate(
+		player.PcId,
+		enteredFlag,
+		update.level,
+		update.hp,
+		update.maxHp,
+		update.location.MapId,
+		update.location.Cell(),
+		player.GateServerSession.agentId,
+	).GetBytes())
+}
+
+// sendPartyUpdates reports the state of every party member in the zone and
+// schedules the next round.
+func (z *Zone) sendPartyUpdates() {
+	for _, pcId := range z.currentPlayers {
+		player, exists := z.players.Get(pcId)
+		if !exists || player.Zone != z || player.Party == nil || player.GateServerSession == nil {
+			continue
+		}
+
+		z.sendPartyUpdate(player, false)
+	}
+
+	z.After(partyUpdateInterval, z.sendPartyUpdates)
+}
+
+// awardKillExp hands out the experience for a kill. A killer in a party
+// shares it with the members close by, each getting a part in proportion to
+// their level, weighted by how far they are from the killer.
+func (z *Zone) awardKillExp(killer *Player, exp uint32) {
+	if killer.Party == nil {
+		z.awardExp(killer, exp)
+		return
+	}
+
+	partyExp := z.zoneManager.GetPartyExpTable()
+	sharers := make([]*Player, 0, len(killer.Party.Members))
+	shares := make([]uint64, 0, len(killer.Party.Members))
+	totalShare := uint64(0)
+	for _, member := range killer.Party.Members {
+		player, exists := z.players.Get(member.PcId)
+		if !exists || player.Zone != z || player.State != PlayerStateInGame || player.IsDead() {
+			continue
+		}
+
+		percent, ok := partyExp.Percent(cellDistance(killer.Location.X, killer.Location.Y, player.Location.X, player.Location.Y))
+		if !ok {
+			continue
+		}
+
+		share := uint64(player.Level) * uint64(percent)
+		sharers = append(sharers, player)
+		shares = append(shares, share)
+		totalShare += share
+	}
+
+	if totalShare == 0 {
+		z.awardExp(killer, exp)
+		return
+	}
+
+	for i, player := range sharers {
+		z.awardExp(player, uint32(uint64(exp)*shares[i]/totalShare))
+	}
+}
+
+func (z *Zone) sendToMainServer(player *Player, packet []byte) {
+	if err := z.zoneManager.SendToMainServer(packet); err != nil {
+		z.logger.Error(
+			"Failed to send packet to main server",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+	}
+}
+
+func newPartyMember(member messages.PartyMember) PartyMember {
+	x, y := CellToXY(member.MapCell)
+	return PartyMember{
+		PcId:     member.PcId,
+		Name:     utils.ReadStringFromBytes(member.Name[:]),
+		Level:    member.Level,
+		HP:       member.HP,
+		MaxHp:    member.MaxHp,
+		Location: Location{MapId: member.MapId, X: x, Y: y},
+	}
+}
@@ -0,0 +1,126 @@
+package zoneserver
+
+import (
+	"testing"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/constants"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/data"
+)
+
+func TestAwardKillExp(t *testing.T) {
+	tests := []struct {
+		name    string
+		party   bool
+		member  func(zone *Zone, member *Player)
+		wantExp [2]uint32
+	}{
+		{
+			name:    "no party",
+			wantExp: [2]uint32{400, 0},
+		},
+		{
+			name:    "split by level",
+			party:   true,
+			wantExp: [2]uint32{100, 300},
+		},
+		{
+			name:  "member further away gets a smaller share",
+			party: true,
+			member: func(zone *Zone, member *Player) {
+				member.Location.X += 10
+			},
+			wantExp: [2]uint32{160, 240},
+		},
+		{
+			name:  "member out of range",
+			party: true,
+			member: func(zone *Zone, member *Player) {
+				member.Location.X += 16
+			},
+			wantExp: [2]uint32{400, 0},
+		},
+		{
+			name:  "dead member",
+			party: true,
+			member: func(zone *Zone, member *Player) {
+				member.Stats.HP = 0
+			},
+			wantExp: [2]uint32{400, 0},
+		},
+		{
+			name:  "member in another zone",
+			party: true,
+			member: func(zone *Zone, member *Player) {
+				member.Zone = &Zone{}
+			},
+			wantExp: [2]uint32{400, 0},
+		},
+		{
+			name:  "member logged out",
+			party: true,
+			member: func(zone *Zone, member *Player) {
+				zone.players.Remove(member.PcId)
+			},
+			wantExp: [2]uint32{400, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			levels := loadTestLevelTable(t)
+			zone := &Zone{
+				players: NewPlayers(),
+				zoneManager: &ZoneManager{
+					classStats: data.ClassStatTable{constants.ClassWarrior: testWarriorStats},
+					levels:     levels,
+					partyExp:   loadTestPartyExpTable(t),
+				},
+			}
+			players := [2]*Player{
+				newTestPartyPlayer(zone, levels, 1, 1),
+				newTestPartyPlayer(zone, levels, 2, 3),
+			}
+			if tt.party {
+				party := &Party{LeaderId: 1, Members: []PartyMember{{PcId: 1}, {PcId: 2}}}
+				players[0].Party, players[1].Party = party, party
+			}
+
+			if tt.member != nil {
+				tt.member(zone, players[1])
+			}
+
+			zone.awardKillExp(players[0], 400)
+			for i, player := range players {
+				levelExp, _ := zone.zoneManager.levels.Exp(player.Level)
+				if got := player.Exp - levelExp; got != tt.wantExp[i] {
+					t.Errorf("player %d got %d exp, want %d", player.PcId, got, tt.wantExp[i])
+				}
+			}
+		})
+	}
+}
+
+func loadTestPartyExpTable(t *testing.T) data.PartyExpTable {
+	t.Helper()
+	partyExp, err := data.LoadPartyExpTable("testdata/party_exp.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return partyExp
+}
+
+func newTestPartyPlayer(zone *Zone, levels data.LevelTable, pcId uint32, level uint16) *Player {
+	levelExp, _ := levels.Exp(level)
+	player := &Player{
+		PcId:     pcId,
+		Class:    constants.ClassWarrior,
+		Level:    level,
+		Exp:      levelExp,
+		Location: Location{X: 100, Y: 100},
+		Stats:    Stats{HP: 100},
+		Zone:     zone,
+		State:    PlayerStateInGame,
+	}
+	zone.players.Add(player)
+	return player
+}
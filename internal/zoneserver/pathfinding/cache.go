@@ -0,0 +1,57 @@
+package pathfinding
+
+import "container/list"
+
+type cacheKey struct {
+	from Point
+	to   Point
+}
+
+type cacheEntry struct {
+	key  cacheKey
+	path []Point
+}
+
+// pathCache is a fixed size LRU cache of previously found paths.
+type pathCache struct {
+	capacity int
+	entries  map[cacheKey]*list.Element
+	order    *list.List
+}
+
+func newPathCache(capacity int) *pathCache {
+	return &pathCache{
+		capacity: capacity,
+		entries:  make(map[cacheKey]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (c *pathCache) get(key cacheKey) ([]Point, bool) {
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	return element.Value.(*cacheEntry).path, true
+}
+
+func (c *pathCache) put(key cacheKey, path []Point) {
+	if c.capacity <= 0 {
+		return
+	}
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*cacheEntry).path = path
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, path: path})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
@@ -0,0 +1,39 @@
+package pathfinding
+
+import "github.com/project-agonyl/open-agonyl-servers/internal/shared/data"
+
+type Point struct {
+	X byte
+	Y byte
+}
+
+// Grid is the walkability source the pathfinder searches over.
+type Grid interface {
+	Width() int
+	Height() int
+	IsWalkable(x int, y int) bool
+}
+
+type mapGrid struct {
+	mapData *data.MapData
+}
+
+func NewMapGrid(mapData *data.MapData) Grid {
+	return &mapGrid{mapData: mapData}
+}
+
+func (g *mapGrid) Width() int {
+	return len(g.mapData.NavigationMesh)
+}
+
+func (g *mapGrid) Height() int {
+	return len(g.mapData.NavigationMesh[0])
+}
+
+func (g *mapGrid) IsWalkable(x int, y int) bool {
+	if x < 0 || y < 0 || x >= g.Width() || y >= g.Height() {
+		return false
+	}
+
+	return g.mapData.NavigationMesh[x][y].IsMovable
+}
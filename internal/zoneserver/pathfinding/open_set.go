@@ -0,0 +1,49 @@
+package pathfinding
+
+type node struct {
+	point  Point
+	g      int
+	h      int
+	parent *node
+	closed bool
+	index  int
+}
+
+// openSet is a min-heap of nodes ordered by their estimated total cost.
+type openSet []*node
+
+func (s openSet) Len() int {
+	return len(s)
+}
+
+func (s openSet) Less(i, j int) bool {
+	fi := s[i].g + s[i].h
+	fj := s[j].g + s[j].h
+	if fi == fj {
+		return s[i].h < s[j].h
+	}
+
+	return fi < fj
+}
+
+func (s openSet) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+	s[i].index = i
+	s[j].index = j
+}
+
+func (s *openSet) Push(x any) {
+	n := x.(*node)
+	n.index = len(*s)
+	*s = append(*s, n)
+}
+
+func (s *openSet) Pop() any {
+	old := *s
+	n := len(old)
+	last := old[n-1]
+	old[n-1] = nil
+	last.index = -1
+	*s = old[:n-1]
+	return last
+}
@@ -0,0 +1,230 @@
+package pathfinding
+
+import (
+	"container/heap"
+	"errors"
+	"slices"
+)
+
+const (
+	straightCost = 10
+	diagonalCost = 14
+)
+
+var (
+	ErrNotWalkable        = errors.New("start or goal is not walkable")
+	ErrNoPath             = errors.New("no path found")
+	ErrNodeBudgetExceeded = errors.New("node budget exceeded")
+)
+
+var directions = [8][2]int{
+	{1, 0}, {-1, 0}, {0, 1}, {0, -1},
+	{1, 1}, {1, -1}, {-1, 1}, {-1, -1},
+}
+
+// Pathfinder runs A* searches over a Grid. Each search expands at most
+// maxNodes nodes and found paths are cached. It is not goroutine-safe.
+type Pathfinder struct {
+	grid     Grid
+	maxNodes int
+	cache    *pathCache
+}
+
+func NewPathfinder(grid Grid, maxNodes int, cacheSize int) *Pathfinder {
+	return &Pathfinder{
+		grid:     grid,
+		maxNodes: maxNodes,
+		cache:    newPathCache(cacheSize),
+	}
+}
+
+// FindPath returns the cells to walk from start to goal, excluding start and
+// including goal. An empty path is returned when start equals goal. The path
+// is the caller's own copy, so changing it does not affect the cache.
+func (p *Pathfinder) FindPath(start Point, goal Point) ([]Point, error) {
+	if !p.isWalkable(start) || !p.isWalkable(goal) {
+		return nil, ErrNotWalkable
+	}
+
+	if start == goal {
+		return []Point{}, nil
+	}
+
+	key := cacheKey{from: start, to: goal}
+	if path, ok := p.cache.get(key); ok {
+		return slices.Clone(path), nil
+	}
+
+	path, err := p.search(start, goal)
+	if err != nil {
+		return nil, err
+	}
+
+	p.cache.put(key, path)
+	return slices.Clone(path), nil
+}
+
+// ValidatePath reports whether path is a walkable sequence of adjacent steps
+// starting next to start.
+func (p *Pathfinder) ValidatePath(start Point, path []Point) bool {
+	previous := start
+	for _, point := range path {
+		if !p.canStep(previous, point) {
+			return false
+		}
+
+		previous = point
+	}
+
+	return true
+}
+
+// HasLineOfSight reports whether every cell on the straight line between from
+// and to is walkable.
+func (p *Pathfinder) HasLineOfSight(from Point, to Point) bool {
+	x, y := int(from.X), int(from.Y)
+	toX, toY := int(to.X), int(to.Y)
+	dx, dy := abs(toX-x), -abs(toY-y)
+	sx, sy := 1, 1
+	if toX < x {
+		sx = -1
+	}
+
+	if toY < y {
+		sy = -1
+	}
+
+	e := dx + dy
+	for {
+		if !p.grid.IsWalkable(x, y) {
+			return false
+		}
+
+		if x == toX && y == toY {
+			return true
+		}
+
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x += sx
+		}
+
+		if e2 <= dx {
+			e += dx
+			y += sy
+		}
+	}
+}
+
+func (p *Pathfinder) search(start Point, goal Point) ([]Point, error) {
+	nodes := make(map[Point]*node)
+	open := &openSet{}
+	startNode := &node{point: start, h: heuristic(start, goal)}
+	nodes[start] = startNode
+	heap.Push(open, startNode)
+	expanded := 0
+	for open.Len() > 0 {
+		current := heap.Pop(open).(*node)
+		if current.point == goal {
+			return buildPath(current), nil
+		}
+
+		current.closed = true
+		expanded++
+		if expanded > p.maxNodes {
+			return nil, ErrNodeBudgetExceeded
+		}
+
+		for _, direction := range directions {
+			x := int(current.point.X) + direction[0]
+			y := int(current.point.Y) + direction[1]
+			if x < 0 || y < 0 || x > 0xFF || y > 0xFF {
+				continue
+			}
+
+			next := Point{X: byte(x), Y: byte(y)}
+			if !p.canStep(current.point, next) {
+				continue
+			}
+
+			cost := straightCost
+			if direction[0] != 0 && direction[1] != 0 {
+				cost = diagonalCost
+			}
+
+			g := current.g + cost
+			neighbour, seen := nodes[next]
+			if seen && (neighbour.closed || g >= neighbour.g) {
+				continue
+			}
+
+			if !seen {
+				neighbour = &node{point: next, h: heuristic(next, goal)}
+				nodes[next] = neighbour
+			}
+
+			neighbour.g = g
+			neighbour.parent = current
+			if seen && neighbour.index >= 0 {
+				heap.Fix(open, neighbour.index)
+			} else {
+				heap.Push(open, neighbour)
+			}
+		}
+	}
+
+	return nil, ErrNoPath
+}
+
+func (p *Pathfinder) IsWalkable(x byte, y byte) bool {
+	return p.grid.IsWalkable(int(x), int(y))
+}
+
+func (p *Pathfinder) isWalkable(point Point) bool {
+	return p.grid.IsWalkable(int(point.X), int(point.Y))
+}
+
+// canStep reports whether a single step between adjacent cells is allowed.
+// Diagonal steps may not cut the corner of a blocked cell.
+func (p *Pathfinder) canStep(from Point, to Point) bool {
+	dx := int(to.X) - int(from.X)
+	dy := int(to.Y) - int(from.Y)
+	if abs(dx) > 1 || abs(dy) > 1 || (dx == 0 && dy == 0) || !p.isWalkable(to) {
+		return false
+	}
+
+	if dx != 0 && dy != 0 {
+		return p.grid.IsWalkable(int(from.X)+dx, int(from.Y)) && p.grid.IsWalkable(int(from.X), int(from.Y)+dy)
+	}
+
+	return true
+}
+
+func buildPath(goal *node) []Point {
+	path := make([]Point, 0)
+	for current := goal; current.parent != nil; current = current.parent {
+		path = append(path, current.point)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path
+}
+
+// heuristic is the octile distance between two cells.
+func heuristic(from Point, to Point) int {
+	dx := abs(int(to.X) - int(from.X))
+	dy := abs(int(to.Y) - int(from.Y))
+	return straightCost*(dx+dy) + (diagonalCost-2*straightCost)*min(dx, dy)
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
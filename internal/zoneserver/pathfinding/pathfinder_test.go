@@ -0,0 +1,179 @@
+package pathfinding
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/data"
+)
+
+// newTestGrid builds a map from rows of text, where '#' is a blocked cell
+// and anything else can be walked on. Cells outside the rows are blocked.
+func newTestGrid(rows ...string) Grid {
+	mapData := &data.MapData{}
+	for y, row := range rows {
+		for x, cell := range row {
+			mapData.NavigationMesh[x][y] = data.NavigationData{IsMovable: cell != '#'}
+		}
+	}
+
+	return NewMapGrid(mapData)
+}
+
+func TestFindPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    []string
+		start   Point
+		goal    Point
+		want    int
+		wantErr error
+	}{
+		{
+			name:  "straight line",
+			rows:  []string{"....."},
+			start: Point{X: 0, Y: 0},
+			goal:  Point{X: 4, Y: 0},
+			want:  4,
+		},
+		{
+			name:  "around a wall",
+			rows:  []string{"..#..", "..#..", "....."},
+			start: Point{X: 0, Y: 0},
+			goal:  Point{X: 4, Y: 0},
+			want:  6,
+		},
+		{
+			name:    "blocked route",
+			rows:    []string{"..#..", "..#..", "..#.."},
+			start:   Point{X: 0, Y: 0},
+			goal:    Point{X: 4, Y: 0},
+			wantErr: ErrNoPath,
+		},
+		{
+			name:    "goal not walkable",
+			rows:    []string{"...#"},
+			start:   Point{X: 0, Y: 0},
+			goal:    Point{X: 3, Y: 0},
+			wantErr: ErrNotWalkable,
+		},
+		{
+			name:  "diagonal corner cut is not allowed",
+			rows:  []string{".#", ".."},
+			start: Point{X: 0, Y: 0},
+			goal:  Point{X: 1, Y: 1},
+			want:  2,
+		},
+		{
+			name:    "diagonal gap between two corners is closed",
+			rows:    []string{".#", "#."},
+			start:   Point{X: 0, Y: 0},
+			goal:    Point{X: 1, Y: 1},
+			wantErr: ErrNoPath,
+		},
+		{
+			name:  "start equals goal",
+			rows:  []string{"."},
+			start: Point{X: 0, Y: 0},
+			goal:  Point{X: 0, Y: 0},
+			want:  0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pathfinder := NewPathfinder(newTestGrid(tt.rows...), 1000, 0)
+			path, err := pathfinder.FindPath(tt.start, tt.goal)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("FindPath() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if len(path) != tt.want {
+				t.Fatalf("FindPath() returned %d steps, want %d: %v", len(path), tt.want, path)
+			}
+
+			if !pathfinder.ValidatePath(tt.start, path) {
+				t.Fatalf("FindPath() returned a path that does not validate: %v", path)
+			}
+
+			if len(path) > 0 && path[len(path)-1] != tt.goal {
+				t.Fatalf("FindPath() ends at %v, want %v", path[len(path)-1], tt.goal)
+			}
+		})
+	}
+}
+
+func TestFindPathNodeBudget(t *testing.T) {
+	rows := []string{
+		"..........",
+		"..........",
+		"..........",
+		"..........",
+		"..........",
+	}
+	pathfinder := NewPathfinder(newTestGrid(rows...), 3, 0)
+	if _, err := pathfinder.FindPath(Point{X: 0, Y: 0}, Point{X: 9, Y: 4}); !errors.Is(err, ErrNodeBudgetExceeded) {
+		t.Fatalf("FindPath() error = %v, want %v", err, ErrNodeBudgetExceeded)
+	}
+
+	pathfinder = NewPathfinder(newTestGrid(rows...), 1000, 0)
+	if _, err := pathfinder.FindPath(Point{X: 0, Y: 0}, Point{X: 9, Y: 4}); err != nil {
+		t.Fatalf("FindPath() with a large budget error = %v", err)
+	}
+}
+
+func TestFindPathReturnsCopyOfCachedPath(t *testing.T) {
+	pathfinder := NewPathfinder(newTestGrid("....."), 1000, 4)
+	start, goal := Point{X: 0, Y: 0}, Point{X: 4, Y: 0}
+	first, err := pathfinder.FindPath(start, goal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first[0] = Point{X: 0xFF, Y: 0xFF}
+	second, err := pathfinder.FindPath(start, goal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if second[0] != (Point{X: 1, Y: 0}) {
+		t.Fatalf("changing a returned path changed the cache, got %v", second[0])
+	}
+}
+
+func TestPathCache(t *testing.T) {
+	cache := newPathCache(2)
+	a := cacheKey{from: Point{X: 0}, to: Point{X: 1}}
+	b := cacheKey{from: Point{X: 0}, to: Point{X: 2}}
+	c := cacheKey{from: Point{X: 0}, to: Point{X: 3}}
+	cache.put(a, []Point{{X: 1}})
+	cache.put(b, []Point{{X: 1}, {X: 2}})
+	if path, ok := cache.get(a); !ok || len(path) != 1 {
+		t.Fatalf("get(a) = %v, %v, want a hit", path, ok)
+	}
+
+	cache.put(c, []Point{{X: 1}, {X: 2}, {X: 3}})
+	if _, ok := cache.get(b); ok {
+		t.Fatal("least recently used entry b was not evicted")
+	}
+
+	if _, ok := cache.get(a); !ok {
+		t.Fatal("recently used entry a was evicted")
+	}
+
+	if path, ok := cache.get(c); !ok || len(path) != 3 {
+		t.Fatalf("get(c) = %v, %v, want a hit", path, ok)
+	}
+}
+
+func TestPathCacheDisabled(t *testing.T) {
+	cache := newPathCache(0)
+	key := cacheKey{from: Point{X: 0}, to: Point{X: 1}}
+	cache.put(key, []Point{{X: 1}})
+	if _, ok := cache.get(key); ok {
+		t.Fatal("cache with no capacity returned a hit")
+	}
+}
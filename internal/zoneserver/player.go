@@ -1,9 +1,14 @@
 package zoneserver
 
 import (
+	"encoding/binary"
 	"fmt"
+	"time"
 
 	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/constants"
+	"github.com/project-agonyl/open-agonyl-servers/internal/zoneserver/db"
+	"github.com/project-agonyl/open-agonyl-servers/internal/zoneserver/pathfinding"
 )
 
 type PlayerState int
@@ -12,10 +17,13 @@ const (
 	PlayerStateWorldLoginPending PlayerState = iota
 	PlayerStateWorldLoginSuccess
 	PlayerStateInGame
+	PlayerStateChangingZone
+	PlayerStateLoggingOut
 )
 
 type Player struct {
 	PcId              uint32
+	CharacterId       uint32
 	Account           string
 	CharacterName     string
 	Class             byte
@@ -23,20 +31,37 @@ type Player struct {
 	Exp               uint32
 	Location          Location
 	Skills            []Skill
+	PassiveSkills     []Skill
+	SkillSlots        [0x14]byte
 	PKCount           uint32
 	RTime             uint32
 	SocialInfo        SocialInfo
 	Woonz             uint32
 	Lore              uint32
+	ChatWindowOption  uint32
 	Stats             Stats
 	Wear              []WearItem
 	Inventory         []InventoryItem
 	ActivePet         Pet
 	PetInventory      []PetInventory
+	Movement          Movement
+	NextAttackAt      time.Time
+	Mercenary         *Mercenary
 	GateServerSession *zoneServerSession
 	Logger            shared.Logger
 	Zone              *Zone
 	State             PlayerState
+	CharacterVersion  uint64
+	characterData     db.CharacterData
+	save              playerSaveState
+	warpOrigin        Location
+	storage           *playerStorage
+	cooldowns         map[byte]time.Time
+	skillCooldowns    map[byte]time.Time
+	Party             *Party
+	lastPartyUpdate   partyUpdate
+	clanInvite        *clanInvite
+	clanBusy          bool
 }
 
 func NewPlayer(
@@ -94,12 +119,63 @@ func (p *Player) Send(packet []byte) error {
 	return p.GateServerSession.Send(packet)
 }
 
+// Relay sends a copy of a packet built for another player, re-stamped with
+// this player's pcId so the gate server can route it.
+func (p *Player) Relay(packet []byte) error {
+	relayed := make([]byte, len(packet))
+	copy(relayed, packet)
+	binary.LittleEndian.PutUint32(relayed[4:], p.PcId)
+	return p.Send(relayed)
+}
+
+func (p *Player) IsDead() bool {
+	return p.Stats.HP == 0
+}
+
 type Location struct {
 	MapId uint16
 	X     byte
 	Y     byte
 }
 
+// Cell returns the location packed into the map cell format used by the client.
+func (l Location) Cell() uint32 {
+	return uint32(l.Y)<<8 | uint32(l.X)
+}
+
+func CellToXY(cell uint32) (byte, byte) {
+	return byte(cell), byte(cell >> 8)
+}
+
+// Movement is the move in progress. Steps have to follow Path, the route to
+// Target found when the move started, from PathIndex on. Budget is how many
+// cells could still be covered at BudgetAt; it grows with time and each step
+// spends from it, so steps sent close together cannot add up to more than
+// the elapsed time allows.
+type Movement struct {
+	IsMoving  bool
+	Target    Location
+	Path      []pathfinding.Point
+	PathIndex int
+	Budget    float64
+	BudgetAt  time.Time
+}
+
+// mercenaryClass is the class mercenaries fight, wear items and raise stats
+// as.
+const mercenaryClass = constants.ClassWarrior
+
+// Mercenary is the hired soldier of a player, saved with the character.
+// Players without a mercenary have a nil Mercenary.
+type Mercenary struct {
+	Id           uint32
+	Location     Location
+	Movement     Movement
+	Stats        Stats
+	Wear         []WearItem
+	NextAttackAt time.Time
+}
+
 type Skill struct {
 	Id    byte
 	Level byte
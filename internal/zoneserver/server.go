@@ -16,6 +16,7 @@ type Server struct {
 	db               db.DBService
 	mainServerClient *MainServerClient
 	players          *Players
+	gateSessions     *GateSessions
 	zoneManager      *ZoneManager
 }
 
@@ -25,6 +26,7 @@ func NewServer(
 	logger shared.Logger,
 	mainServerClient *MainServerClient,
 	players *Players,
+	gateSessions *GateSessions,
 	zoneManager *ZoneManager,
 ) *Server {
 	server := &Server{
@@ -38,6 +40,7 @@ func NewServer(
 		db:               db,
 		mainServerClient: mainServerClient,
 		players:          players,
+		gateSessions:     gateSessions,
 		zoneManager:      zoneManager,
 	}
 
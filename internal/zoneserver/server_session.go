@@ -51,6 +51,7 @@ func (s *zoneServerSession) ID() uint32 {
 func (s *zoneServerSession) Handle() {
 	defer func() {
 		s.server.Logger.Info(fmt.Sprintf("Gate server %d disconnected", s.agentId))
+		s.server.gateSessions.Remove(s.agentId, s)
 		s.server.RemoveSession(s.id)
 		close(s.done)
 		s.wg.Wait()
@@ -106,12 +107,29 @@ func (s *zoneServerSession) SendErrorMsg(pcId uint32, errorCode uint16, errorMsg
 }
 
 func (s *zoneServerSession) processPacket(packet []byte) {
-	if len(packet) < 12 {
+	if len(packet) < 10 {
 		return
 	}
 
 	ctrl := packet[8]
 	cmd := packet[9]
+	if ctrl == 0x01 {
+		switch cmd {
+		case 0xE0:
+			s.handleGateConnect(packet)
+		case 0xE2:
+			s.handleAccLogout(packet)
+		default:
+			s.server.Logger.Error("Unhandled packet", shared.Field{Key: "ctrl", Value: ctrl}, shared.Field{Key: "cmd", Value: cmd})
+		}
+
+		return
+	}
+
+	if len(packet) < 12 {
+		return
+	}
+
 	proto := binary.LittleEndian.Uint16(packet[10:])
 	pcId := binary.LittleEndian.Uint32(packet[4:])
 	switch proto {
@@ -159,6 +177,44 @@ func (s *zoneServerSession) processPacket(packet []byte) {
 	}
 }
 
+func (s *zoneServerSession) handleGateConnect(packet []byte) {
+	msg, err := messages.ReadMsgGate2ZsConnect(packet)
+	if err != nil {
+		s.server.Logger.Error(
+			"Failed to read Gate2ZsConnect message",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "packet", Value: packet},
+		)
+		return
+	}
+
+	s.server.Logger.Info(fmt.Sprintf("Gate server %d connected", msg.AgentID))
+	s.agentId = msg.AgentID
+	s.server.gateSessions.Add(s.agentId, s)
+}
+
+func (s *zoneServerSession) handleAccLogout(packet []byte) {
+	msg, err := messages.ReadMsgZa2ZsAccLogout(packet)
+	if err != nil {
+		s.server.Logger.Error(
+			"Failed to read Za2ZsAccLogout message",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "packet", Value: packet},
+		)
+		return
+	}
+
+	player, exists := s.server.players.Get(msg.PcId)
+	if !exists {
+		s.server.Logger.Debug("Logout for unknown player", shared.Field{Key: "pcId", Value: msg.PcId})
+		return
+	}
+
+	if !player.Zone.EnqueuePlayerLogout(msg.PcId) {
+		s.server.Logger.Error("Failed to enqueue player logout", shared.Field{Key: "pcId", Value: msg.PcId})
+	}
+}
+
 func (s *zoneServerSession) sender() {
 	defer s.wg.Done()
 	for {
@@ -0,0 +1,224 @@
+package zoneserver
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages"
+)
+
+// maxSkillLevel is the highest skill level the client can show, one skill
+// bitmask per level.
+const maxSkillLevel = 3
+
+// handleLearnSkill teaches the player the skill of an IT2 skill book, which
+// is used up. Books teach one level of a skill and have to be read in order.
+func (z *Zone) handleLearnSkill(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SLearnSkill(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read learn skill",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	fail := func() {
+		_ = player.Send(messages.NewMsgS2CLearnSkill(player.PcId, msg.Slot, 0, 0, itemResultFailure).GetBytes())
+	}
+	index := findInventorySlot(player.Inventory, msg.Slot)
+	if index < 0 {
+		fail()
+		return
+	}
+
+	book := player.Inventory[index]
+	itemData, err := z.zoneManager.GetItemData(book.ItemCode)
+	if err != nil || itemData.IT2Property == nil ||
+		byte(itemData.IT2Property.Class) != player.Class ||
+		player.Level < itemData.IT2Property.RequiredLevel {
+		fail()
+		return
+	}
+
+	skillId, ok := z.zoneManager.FindSkillByBook(player.Class, book.ItemCode)
+	if !ok {
+		fail()
+		return
+	}
+
+	skill, _ := z.zoneManager.GetSkill(player.Class, skillId)
+	level := byte(itemData.IT2Property.SkillLevel)
+	if _, ok := skill.Level(level); !ok || skill.Passive || level > maxSkillLevel {
+		fail()
+		return
+	}
+
+	skillIndex := findSkill(player.Skills, skillId)
+	switch {
+	case skillIndex < 0 && level == 1:
+		player.Skills = append(player.Skills, Skill{Id: skillId, Level: level})
+	case skillIndex >= 0 && player.Skills[skillIndex].Level+1 == level:
+		player.Skills[skillIndex].Level = level
+	default:
+		fail()
+		return
+	}
+
+	player.Inventory = slices.Delete(player.Inventory, index, index+1)
+	player.MarkDirty()
+	z.savePlayer(player, nil)
+	_ = player.Send(messages.NewMsgS2CLearnSkill(player.PcId, msg.Slot, skillId, level, itemResultSuccess).GetBytes())
+}
+
+// handleAskSkill casts one of the player's active skills on the target. The
+// skill costs MP and hits through the normal combat rules at its own range
+// and strength, after which it cannot be cast again until its cooldown has
+// passed on the server's clock.
+func (z *Zone) handleAskSkill(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SAskSkill(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read ask skill",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	skillIndex := findSkill(player.Skills, msg.SkillId)
+	if player.IsDead() || skillIndex < 0 {
+		return
+	}
+
+	skill, ok := z.zoneManager.GetSkill(player.Class, msg.SkillId)
+	if !ok || skill.Passive {
+		return
+	}
+
+	skillLevel := player.Skills[skillIndex].Level
+	level, ok := skill.Level(skillLevel)
+	now := time.Now()
+	if !ok || player.Stats.MP < level.MPCost || now.Before(player.skillCooldowns[msg.SkillId]) {
+		return
+	}
+
+	if !z.attack(player, player.PcId, player.Location, int(level.Range), player.Class, player.Stats, msg.TargetId, int(level.DamagePercent)) {
+		return
+	}
+
+	if player.skillCooldowns == nil {
+		player.skillCooldowns = make(map[byte]time.Time)
+	}
+
+	player.skillCooldowns[msg.SkillId] = now.Add(time.Duration(level.CooldownMs) * time.Millisecond)
+	player.Stats.MP -= level.MPCost
+	player.MarkDirty()
+	cast := messages.NewMsgS2CSkill(player.PcId, player.PcId, msg.TargetId, msg.SkillId, skillLevel).GetBytes()
+	z.BroadcastNearby(player.Location.X, player.Location.Y, cast, 0)
+	_ = player.Send(newPcStatsMsg(player).GetBytes())
+}
+
+func (z *Zone) handleSkillSlotInfo(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SSkillSlotInfo(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read skill slot info",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	player.SkillSlots = msg.SkillSlots
+	player.MarkDirty()
+}
+
+// handleLearnPskill buys the next level of a passive skill with Lore.
+func (z *Zone) handleLearnPskill(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SLearnPskill(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read learn pskill",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	fail := func() {
+		_ = player.Send(messages.NewMsgS2CLearnPskill(player.PcId, msg.SkillId, 0, player.Lore, itemResultFailure).GetBytes())
+	}
+	skill, ok := z.zoneManager.GetSkill(player.Class, msg.SkillId)
+	if !ok || !skill.Passive {
+		fail()
+		return
+	}
+
+	skillIndex := findSkill(player.PassiveSkills, msg.SkillId)
+	nextLevel := byte(1)
+	if skillIndex >= 0 {
+		nextLevel = player.PassiveSkills[skillIndex].Level + 1
+	}
+
+	level, ok := skill.Level(nextLevel)
+	if !ok || player.Level < level.RequiredLevel || player.Lore < level.LoreCost {
+		fail()
+		return
+	}
+
+	player.Lore -= level.LoreCost
+	if skillIndex < 0 {
+		player.PassiveSkills = append(player.PassiveSkills, Skill{Id: msg.SkillId, Level: nextLevel})
+	} else {
+		player.PassiveSkills[skillIndex].Level = nextLevel
+	}
+
+	player.MarkDirty()
+	z.recalculateStats(player)
+	_ = player.Send(messages.NewMsgS2CLearnPskill(player.PcId, msg.SkillId, nextLevel, player.Lore, itemResultSuccess).GetBytes())
+	_ = player.Send(newPcStatsMsg(player).GetBytes())
+}
+
+// handleForgetAllPskill forgets every passive skill of the player and gives
+// back the Lore spent on them.
+func (z *Zone) handleForgetAllPskill(player *Player, packet []byte) {
+	if _, err := messages.ReadMsgC2SForgetAllPskill(packet); err != nil {
+		z.logger.Error(
+			"Failed to read forget all pskill",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	lore := uint64(player.Lore)
+	for _, passive := range player.PassiveSkills {
+		skill, ok := z.zoneManager.GetSkill(player.Class, passive.Id)
+		if !ok {
+			continue
+		}
+
+		for level := byte(1); level <= passive.Level; level++ {
+			if levelData, ok := skill.Level(level); ok {
+				lore += uint64(levelData.LoreCost)
+			}
+		}
+	}
+
+	player.Lore = uint32(min(lore, math.MaxUint32))
+	player.PassiveSkills = nil
+	player.MarkDirty()
+	z.recalculateStats(player)
+	_ = player.Send(messages.NewMsgS2CForgetAllPskill(player.PcId, player.Lore, itemResultSuccess).GetBytes())
+	_ = player.Send(newPcStatsMsg(player).GetBytes())
+}
+
+func findSkill(skills []Skill, skillId byte) int {
+	return slices.IndexFunc(skills, func(skill Skill) bool {
+		return skill.Id == skillId
+	})
+}
@@ -0,0 +1,229 @@
+package zoneserver
+
+import (
+	"math"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/data"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages"
+)
+
+// The base stats points can be allotted to, in the order the client numbers
+// them.
+const (
+	statStrength byte = iota
+	statIntelligence
+	statDexterity
+	statVitality
+	statMana
+)
+
+func (z *Zone) handleAllotPoint(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SAllotPoint(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read allot point",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	bounds, ok := z.getClassStats(player, player.Class)
+	if ok && allotPoints(&player.Stats, bounds, msg.Stat, msg.Points) {
+		z.updatePlayerStats(player)
+		return
+	}
+
+	_ = player.Send(newPcStatsMsg(player).GetBytes())
+}
+
+func (z *Zone) handleRetrievePoint(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SRetrievePoint(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read retrieve point",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	bounds, ok := z.getClassStats(player, player.Class)
+	if !ok {
+		_ = player.Send(messages.NewMsgS2CRetrievePoint(player.PcId, msg.Stat, msg.Points, player.Woonz, itemResultFailure).GetBytes())
+		return
+	}
+
+	cost, ok := payForRetrieve(player, bounds.RetrievePointCost, msg.Points)
+	if !ok || !retrievePoints(&player.Stats, bounds, msg.Stat, msg.Points) {
+		player.Woonz += cost
+		_ = player.Send(messages.NewMsgS2CRetrievePoint(player.PcId, msg.Stat, msg.Points, player.Woonz, itemResultFailure).GetBytes())
+		return
+	}
+
+	_ = player.Send(messages.NewMsgS2CRetrievePoint(player.PcId, msg.Stat, msg.Points, player.Woonz, itemResultSuccess).GetBytes())
+	z.updatePlayerStats(player)
+}
+
+func (z *Zone) handleHsAllotPoint(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SHsAllotPoint(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read hs allot point",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	mercenary := z.getMercenary(player, msg.HsId)
+	result := itemResultFailure
+	var remainingPoints uint16
+	if mercenary != nil {
+		bounds, ok := z.getClassStats(player, mercenaryClass)
+		if ok && allotPoints(&mercenary.Stats, bounds, msg.Stat, msg.Points) {
+			result = itemResultSuccess
+			z.recalculateStats(player)
+			player.MarkDirty()
+			z.savePlayer(player, nil)
+		}
+
+		remainingPoints = mercenary.Stats.RemainingPoints
+	}
+
+	_ = player.Send(messages.NewMsgS2CHsAllotPoint(player.PcId, msg.HsId, msg.Stat, msg.Points, remainingPoints, result).GetBytes())
+}
+
+// handleHsRetrievePoint takes points back from the mercenary. Like for the
+// player's own stats, the player pays for it.
+func (z *Zone) handleHsRetrievePoint(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SHsRetrievePoint(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read hs retrieve point",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	mercenary := z.getMercenary(player, msg.HsId)
+	bounds, ok := z.getClassStats(player, mercenaryClass)
+	if mercenary == nil || !ok {
+		_ = player.Send(messages.NewMsgS2CHsRetrievePoint(player.PcId, msg.HsId, msg.Stat, msg.Points, 0, player.Woonz, itemResultFailure).GetBytes())
+		return
+	}
+
+	cost, ok := payForRetrieve(player, bounds.RetrievePointCost, msg.Points)
+	if !ok || !retrievePoints(&mercenary.Stats, bounds, msg.Stat, msg.Points) {
+		player.Woonz += cost
+		_ = player.Send(messages.NewMsgS2CHsRetrievePoint(
+			player.PcId,
+			msg.HsId,
+			msg.Stat,
+			msg.Points,
+			mercenary.Stats.RemainingPoints,
+			player.Woonz,
+			itemResultFailure,
+		).GetBytes())
+		return
+	}
+
+	z.recalculateStats(player)
+	player.MarkDirty()
+	z.savePlayer(player, nil)
+	_ = player.Send(messages.NewMsgS2CHsRetrievePoint(
+		player.PcId,
+		msg.HsId,
+		msg.Stat,
+		msg.Points,
+		mercenary.Stats.RemainingPoints,
+		player.Woonz,
+		itemResultSuccess,
+	).GetBytes())
+}
+
+// updatePlayerStats applies a change of base stats: the calculated stats are
+// worked out again, sent to the player and saved.
+func (z *Zone) updatePlayerStats(player *Player) {
+	z.recalculateStats(player)
+	player.MarkDirty()
+	z.savePlayer(player, nil)
+	_ = player.Send(newPcStatsMsg(player).GetBytes())
+}
+
+// getClassStats returns the stat bounds of the class the player changes the
+// stats of. A class missing from the class stat table is logged, as none of
+// its points can be moved until it is added.
+func (z *Zone) getClassStats(player *Player, class byte) (data.ClassStats, bool) {
+	bounds, exists := z.zoneManager.GetClassStats(class)
+	if !exists {
+		z.logger.Error(
+			"Class not found in class stat table",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "pcId", Value: player.PcId},
+			shared.Field{Key: "class", Value: class},
+		)
+	}
+
+	return bounds, exists
+}
+
+// payForRetrieve takes the cost of retrieving the points from the player and
+// returns it, so it can be given back if the retrieve does not go through.
+func payForRetrieve(player *Player, pointCost uint32, points uint16) (uint32, bool) {
+	cost := uint64(pointCost) * uint64(points)
+	if points == 0 || cost > math.MaxUint32 || uint32(cost) > player.Woonz {
+		return 0, false
+	}
+
+	player.Woonz -= uint32(cost)
+	return uint32(cost), true
+}
+
+// allotPoints moves remaining points into a base stat, up to the cap of the
+// class.
+func allotPoints(stats *Stats, bounds data.ClassStats, stat byte, points uint16) bool {
+	value := baseStat(stats, stat)
+	if value == nil || points == 0 || points > stats.RemainingPoints ||
+		uint32(*value)+uint32(points) > uint32(bounds.Caps[stat]) {
+		return false
+	}
+
+	*value += points
+	stats.RemainingPoints -= points
+	return true
+}
+
+// retrievePoints moves points from a base stat back to the remaining points,
+// down to what the class starts with.
+func retrievePoints(stats *Stats, bounds data.ClassStats, stat byte, points uint16) bool {
+	value := baseStat(stats, stat)
+	if value == nil || points == 0 || *value < points ||
+		*value-points < bounds.Floors[stat] ||
+		uint32(stats.RemainingPoints)+uint32(points) > math.MaxUint16 {
+		return false
+	}
+
+	*value -= points
+	stats.RemainingPoints += points
+	return true
+}
+
+func baseStat(stats *Stats, stat byte) *uint16 {
+	switch stat {
+	case statStrength:
+		return &stats.Strength
+	case statIntelligence:
+		return &stats.Intelligence
+	case statDexterity:
+		return &stats.Dexterity
+	case statVitality:
+		return &stats.Vitality
+	case statMana:
+		return &stats.Mana
+	default:
+		return nil
+	}
+}
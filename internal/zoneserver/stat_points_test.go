@@ -0,0 +1,66 @@
+package zoneserver
+
+import "testing"
+
+func TestAllotPoints(t *testing.T) {
+	tests := []struct {
+		name         string
+		stats        Stats
+		stat         byte
+		points       uint16
+		want         bool
+		wantStrength uint16
+	}{
+		{"within the cap", Stats{Strength: 30, RemainingPoints: 10}, statStrength, 10, true, 40},
+		{"past the cap", Stats{Strength: 90, RemainingPoints: 20}, statStrength, 11, false, 90},
+		{"more than remaining", Stats{Strength: 30, RemainingPoints: 10}, statStrength, 11, false, 30},
+		{"stat the class cannot raise", Stats{Strength: 30, RemainingPoints: 10}, statIntelligence, 1, false, 30},
+		{"no points", Stats{Strength: 30, RemainingPoints: 10}, statStrength, 0, false, 30},
+		{"unknown stat", Stats{Strength: 30, RemainingPoints: 10}, 5, 1, false, 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := tt.stats
+			if got := allotPoints(&stats, testWarriorStats, tt.stat, tt.points); got != tt.want {
+				t.Fatalf("allotPoints() = %v, want %v", got, tt.want)
+			}
+
+			wantRemaining := tt.stats.RemainingPoints
+			if tt.want {
+				wantRemaining -= tt.points
+			}
+
+			if stats.Strength != tt.wantStrength || stats.RemainingPoints != wantRemaining {
+				t.Errorf("Strength, RemainingPoints = %d, %d, want %d, %d",
+					stats.Strength, stats.RemainingPoints, tt.wantStrength, wantRemaining)
+			}
+		})
+	}
+}
+
+func TestRetrievePoints(t *testing.T) {
+	tests := []struct {
+		name          string
+		points        uint16
+		want          bool
+		wantStrength  uint16
+		wantRemaining uint16
+	}{
+		{"down to the floor", 10, true, 30, 10},
+		{"below the floor", 11, false, 40, 0},
+		{"no points", 0, false, 40, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := Stats{Strength: 40}
+			if got := retrievePoints(&stats, testWarriorStats, statStrength, tt.points); got != tt.want {
+				t.Fatalf("retrievePoints() = %v, want %v", got, tt.want)
+			}
+
+			if stats.Strength != tt.wantStrength || stats.RemainingPoints != tt.wantRemaining {
+				t.Errorf("Strength, RemainingPoints = %d, %d, want %d, %d",
+					stats.Strength, stats.RemainingPoints, tt.wantStrength, tt.wantRemaining)
+			}
+		})
+	}
+}
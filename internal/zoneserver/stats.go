@@ -0,0 +1,168 @@
+package zoneserver
+
+import (
+	"slices"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/data"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages"
+)
+
+type itemBonus struct {
+	attack           int
+	additionalAttack int
+	defense          int
+	fireAttack       int
+	fireDefense      int
+	iceAttack        int
+	iceDefense       int
+	lightAttack      int
+	lightDefense     int
+	hp               int
+	mp               int
+}
+
+// recalculateStats fills the calculated stats of the player from its class,
+// level, base stats, worn items and passive skills, and caps HP and MP at
+// the new maxima. The player's mercenary is worked out the same way at the
+// player's level. It must be called whenever any of those change. A class
+// missing from the class stat table is logged and only gets what its items
+// and skills add.
+func (z *Zone) recalculateStats(player *Player) {
+	classStats, _ := z.getClassStats(player, player.Class)
+	z.calculateStats(&player.Stats, player.Class, classStats, player.Level, player.Wear, player.PassiveSkills)
+	if player.Mercenary != nil {
+		mercenaryStats, _ := z.getClassStats(player, mercenaryClass)
+		z.calculateStats(&player.Mercenary.Stats, mercenaryClass, mercenaryStats, player.Level, player.Mercenary.Wear, nil)
+	}
+}
+
+func (z *Zone) calculateStats(
+	stats *Stats,
+	class byte,
+	classStats data.ClassStats,
+	playerLevel uint16,
+	wear []WearItem,
+	passiveSkills []Skill,
+) {
+	bonus := itemBonus{}
+	for _, wearItem := range wear {
+		itemData, err := z.zoneManager.GetItemData(wearItem.ItemCode)
+		if err != nil {
+			continue
+		}
+
+		addItemBonus(&bonus, itemData, wearItem.ItemOption)
+	}
+
+	for _, passive := range passiveSkills {
+		skill, ok := z.zoneManager.GetSkill(class, passive.Id)
+		if !ok {
+			continue
+		}
+
+		if level, ok := skill.Level(passive.Level); ok {
+			bonus.attack += int(level.Attack)
+			bonus.defense += int(level.Defense)
+			bonus.hp += int(level.HP)
+			bonus.mp += int(level.MP)
+		}
+	}
+
+	baseStats := [5]uint16{stats.Strength, stats.Intelligence, stats.Dexterity, stats.Vitality, stats.Mana}
+	hitAttack := classStats.HitAttack.Apply(baseStats, playerLevel)
+	magicAttack := classStats.MagicAttack.Apply(baseStats, playerLevel)
+	if classStats.WeaponMagicAttack {
+		magicAttack += bonus.attack
+		stats.AdditionalMagicAttack = clampUint16(bonus.additionalAttack)
+		stats.AdditionalHitAttack = 0
+	} else {
+		hitAttack += bonus.attack
+		stats.AdditionalHitAttack = clampUint16(bonus.additionalAttack)
+		stats.AdditionalMagicAttack = 0
+	}
+
+	stats.HitAttack = clampUint16(hitAttack)
+	stats.MagicAttack = clampUint16(magicAttack)
+	stats.Defense = clampUint16(classStats.Defense.Apply(baseStats, playerLevel) + bonus.defense)
+	stats.FireAttack = clampUint16(bonus.fireAttack)
+	stats.FireDefence = clampUint16(bonus.fireDefense)
+	stats.IceAttack = clampUint16(bonus.iceAttack)
+	stats.IceDefense = clampUint16(bonus.iceDefense)
+	stats.LightAttack = clampUint16(bonus.lightAttack)
+	stats.LightDefense = clampUint16(bonus.lightDefense)
+	stats.MaxHp = clampUint16(int(stats.HPCapacity) + int(stats.Vitality)*int(classStats.HPPerVitality) + bonus.hp)
+	stats.MaxMp = clampUint16(int(stats.MPCapacity) + int(stats.Mana)*int(classStats.MPPerMana) + bonus.mp)
+	stats.HP = min(stats.HP, stats.MaxHp)
+	stats.MP = min(stats.MP, stats.MaxMp)
+}
+
+// addItemBonus adds what a worn item contributes. Weapons add attack and
+// elemental attack, everything else adds defense and elemental defense. The
+// low byte of the item option is the upgrade level of IT0 items. The item
+// tables have no HP or MP column, so items never raise the maxima.
+func addItemBonus(bonus *itemBonus, itemData *data.Item, itemOption uint32) {
+	switch {
+	case itemData.IT0Property != nil && len(itemData.IT0Property.Levels) > 0:
+		levels := itemData.IT0Property.Levels
+		level := levels[min(int(itemOption&0xFF), len(levels)-1)]
+		if isWeapon(itemData) {
+			bonus.attack += int(level.Attribute)
+			bonus.additionalAttack += int(level.AdditionalAttribute)
+			bonus.fireAttack += int(level.RedOption)
+			bonus.iceAttack += int(level.BlueOption)
+			bonus.lightAttack += int(level.GreyOption)
+			return
+		}
+
+		bonus.defense += int(level.Attribute) + int(level.AdditionalAttribute)
+		bonus.fireDefense += int(level.RedOption)
+		bonus.iceDefense += int(level.BlueOption)
+		bonus.lightDefense += int(level.GreyOption)
+	case itemData.IT1Property != nil:
+		bonus.defense += int(itemData.IT1Property.Attribute)
+		bonus.fireDefense += int(itemData.IT1Property.RedOption)
+		bonus.iceDefense += int(itemData.IT1Property.BlueOption)
+		bonus.lightDefense += int(itemData.IT1Property.GreyOption)
+	}
+}
+
+// isWeapon tells weapons from armour by the attack range in the IT0 item
+// data, which only weapons fill in.
+func isWeapon(itemData *data.Item) bool {
+	return itemData.IT0Property != nil && slices.ContainsFunc(itemData.IT0Property.Levels, func(level data.IT0Level) bool {
+		return level.AttributeRange > 0
+	})
+}
+
+func clampUint16(v int) uint16 {
+	return uint16(min(max(v, 0), 0xFFFF))
+}
+
+func newPcStatsMsg(player *Player) *messages.MsgS2CPcStats {
+	stats := player.Stats
+	msg := messages.NewMsgS2CPcStats(player.PcId)
+	msg.RemainingPoints = stats.RemainingPoints
+	msg.Strength = stats.Strength
+	msg.Intelligence = stats.Intelligence
+	msg.Dexterity = stats.Dexterity
+	msg.Vitality = stats.Vitality
+	msg.Mana = stats.Mana
+	msg.HPCapacity = stats.HPCapacity
+	msg.MPCapacity = stats.MPCapacity
+	msg.HP = stats.HP
+	msg.MP = stats.MP
+	msg.HitAttack = stats.HitAttack
+	msg.MagicAttack = stats.MagicAttack
+	msg.Defense = stats.Defense
+	msg.FireAttack = stats.FireAttack
+	msg.FireDefence = stats.FireDefence
+	msg.IceAttack = stats.IceAttack
+	msg.IceDefense = stats.IceDefense
+	msg.LightAttack = stats.LightAttack
+	msg.LightDefense = stats.LightDefense
+	msg.MaxHp = stats.MaxHp
+	msg.MaxMp = stats.MaxMp
+	msg.AdditionalHitAttack = stats.AdditionalHitAttack
+	msg.AdditionalMagicAttack = stats.AdditionalMagicAttack
+	return msg
+}
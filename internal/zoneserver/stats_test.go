@@ -0,0 +1,98 @@
+package zoneserver
+
+import (
+	"testing"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/constants"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/data"
+)
+
+// testWarriorStats is what the stats of a warrior are worked out from in
+// the tests.
+var testWarriorStats = data.ClassStats{
+	Floors:            [5]uint16{30, 0, 16, 30, 20},
+	Caps:              [5]uint16{100, 0, 100, 100, 100},
+	RetrievePointCost: 100,
+	HPPerVitality:     3,
+	MPPerMana:         2,
+	HitAttack:         data.StatFormula{Divisors: [5]uint16{2, 0, 4, 0, 0}, LevelDivisor: 1},
+	MagicAttack:       data.StatFormula{Divisors: [5]uint16{0, 2, 0, 0, 0}, LevelDivisor: 1},
+	Defense:           data.StatFormula{Divisors: [5]uint16{0, 0, 0, 4, 0}, LevelDivisor: 2},
+}
+
+func newTestStatsZone() *Zone {
+	return &Zone{zoneManager: &ZoneManager{
+		classStats: data.ClassStatTable{constants.ClassWarrior: testWarriorStats},
+	}}
+}
+
+func TestRecalculateStats(t *testing.T) {
+	player := &Player{Class: constants.ClassWarrior, Level: 10, Stats: Stats{Strength: 40, Intelligence: 10, Dexterity: 20, Vitality: 30}}
+	newTestStatsZone().recalculateStats(player)
+	stats := player.Stats
+	if stats.HitAttack != 40/2+20/4+10 || stats.MagicAttack != 10/2+10 || stats.Defense != 30/4+10/2 {
+		t.Fatalf("hit/magic/defense = %d/%d/%d", stats.HitAttack, stats.MagicAttack, stats.Defense)
+	}
+}
+
+func TestRecalculateStatsCapsHPAndMP(t *testing.T) {
+	tests := []struct {
+		name      string
+		stats     Stats
+		wantMaxHp uint16
+		wantMaxMp uint16
+		wantHp    uint16
+		wantMp    uint16
+	}{
+		{
+			name:      "maxima come from capacity and base stats",
+			stats:     Stats{Vitality: 30, Mana: 20, HPCapacity: 100, MPCapacity: 50, HP: 10, MP: 10},
+			wantMaxHp: 100 + 30*3,
+			wantMaxMp: 50 + 20*2,
+			wantHp:    10,
+			wantMp:    10,
+		},
+		{
+			name:      "HP and MP above the maxima are capped",
+			stats:     Stats{Vitality: 30, Mana: 20, HPCapacity: 100, MPCapacity: 50, HP: 5000, MP: 5000},
+			wantMaxHp: 100 + 30*3,
+			wantMaxMp: 50 + 20*2,
+			wantHp:    100 + 30*3,
+			wantMp:    50 + 20*2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			player := &Player{Class: constants.ClassWarrior, Level: 1, Stats: tt.stats}
+			newTestStatsZone().recalculateStats(player)
+			stats := player.Stats
+			if stats.MaxHp != tt.wantMaxHp || stats.MaxMp != tt.wantMaxMp {
+				t.Fatalf("maxima = %d/%d, want %d/%d", stats.MaxHp, stats.MaxMp, tt.wantMaxHp, tt.wantMaxMp)
+			}
+
+			if stats.HP != tt.wantHp || stats.MP != tt.wantMp {
+				t.Fatalf("HP/MP = %d/%d, want %d/%d", stats.HP, stats.MP, tt.wantHp, tt.wantMp)
+			}
+		})
+	}
+}
+
+func TestAddItemBonus(t *testing.T) {
+	weapon := &data.Item{IT0Property: &data.IT0Property{Levels: []data.IT0Level{
+		{AttributeRange: 2, Attribute: 10, RedOption: 1},
+		{AttributeRange: 2, Attribute: 20, RedOption: 2},
+	}}}
+	armour := &data.Item{IT0Property: &data.IT0Property{Levels: []data.IT0Level{
+		{Attribute: 5, AdditionalAttribute: 1, BlueOption: 3},
+	}}}
+	accessory := &data.Item{IT1Property: &data.IT1Property{Attribute: 4, GreyOption: 2}}
+
+	bonus := itemBonus{}
+	addItemBonus(&bonus, weapon, 1)
+	addItemBonus(&bonus, armour, 0)
+	addItemBonus(&bonus, accessory, 0)
+	want := itemBonus{attack: 20, fireAttack: 2, defense: 10, iceDefense: 3, lightDefense: 2}
+	if bonus != want {
+		t.Fatalf("addItemBonus() = %+v, want %+v", bonus, want)
+	}
+}
@@ -0,0 +1,417 @@
+package zoneserver
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages"
+	"github.com/project-agonyl/open-agonyl-servers/internal/zoneserver/db"
+)
+
+const (
+	storageSize             = 0x28
+	storageRange            = 6
+	storageCommitRetryDelay = 100 * time.Millisecond
+)
+
+// playerStorage is the account storage a player has open. It is busy while
+// it is being opened or while a change to it is being saved, and the
+// player's inventory may not change until it is done.
+type playerStorage struct {
+	items   []InventoryItem
+	woonz   uint32
+	version uint64
+	busy    bool
+}
+
+// storageChange is what the player's inventory and the storage hold after a
+// storage action.
+type storageChange struct {
+	inventory    []InventoryItem
+	woonz        uint32
+	items        []InventoryItem
+	storageWoonz uint32
+}
+
+func newStorageChange(player *Player, storage *playerStorage) storageChange {
+	return storageChange{
+		inventory:    slices.Clone(player.Inventory),
+		woonz:        player.Woonz,
+		items:        slices.Clone(storage.items),
+		storageWoonz: storage.woonz,
+	}
+}
+
+// handleAskOpenStorage locks the account storage for the player and sends
+// what is in it. The storage is shared by every character of the account,
+// so only one of them can have it open at a time.
+func (z *Zone) handleAskOpenStorage(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SAskOpenStorage(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read ask open storage",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	if player.storage != nil || !z.isNearStorageKeeper(player, msg.NpcId) {
+		_ = player.Send(messages.NewMsgS2COpenStorage(player.PcId, 0, itemResultFailure).GetBytes())
+		return
+	}
+
+	storage := &playerStorage{busy: true}
+	player.storage = storage
+	accountId, characterId := player.PcId, player.CharacterId
+	go func() {
+		stored, err := z.db.OpenStorage(accountId, characterId)
+		posted := z.Post(func() {
+			if player.storage != storage {
+				if err == nil {
+					z.releaseStorage(accountId, characterId)
+				}
+
+				return
+			}
+
+			if err != nil {
+				player.storage = nil
+				if !errors.Is(err, db.ErrStorageLocked) {
+					z.logger.Error(
+						"Failed to open storage",
+						shared.Field{Key: "error", Value: err},
+						shared.Field{Key: "mapId", Value: z.mapId},
+						shared.Field{Key: "pcId", Value: player.PcId},
+					)
+				}
+
+				_ = player.Send(messages.NewMsgS2COpenStorage(player.PcId, 0, itemResultFailure).GetBytes())
+				return
+			}
+
+			storage.items = make([]InventoryItem, len(stored.Items))
+			for i, item := range stored.Items {
+				storage.items[i] = InventoryItem{
+					ItemCode:       item.ItemCode,
+					ItemOption:     item.ItemOption,
+					ItemUniqueCode: item.ItemUniqueCode,
+					Slot:           item.Slot,
+				}
+			}
+
+			storage.woonz = stored.Woonz
+			storage.version = stored.Version
+			storage.busy = false
+			_ = player.Send(newOpenStorageMsg(player.PcId, storage).GetBytes())
+		})
+		if !posted {
+			z.logger.Error(
+				"Failed to post open storage result",
+				shared.Field{Key: "mapId", Value: z.mapId},
+				shared.Field{Key: "pcId", Value: accountId},
+			)
+		}
+	}()
+}
+
+func (z *Zone) handleAskInven2Storage(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SAskInven2Storage(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read ask inven to storage",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	reply := func(result byte) {
+		_ = player.Send(messages.NewMsgS2CInven2Storage(player.PcId, msg.InventorySlot, msg.StorageSlot, result).GetBytes())
+	}
+	storage := player.storage
+	index := findInventorySlot(player.Inventory, msg.InventorySlot)
+	if storage == nil || index < 0 || msg.StorageSlot >= storageSize || findInventorySlot(storage.items, msg.StorageSlot) >= 0 {
+		reply(itemResultFailure)
+		return
+	}
+
+	change := newStorageChange(player, storage)
+	item := change.inventory[index]
+	item.Slot = msg.StorageSlot
+	change.inventory = slices.Delete(change.inventory, index, index+1)
+	change.items = append(change.items, item)
+	z.commitStorage(player, change, reply)
+}
+
+func (z *Zone) handleAskStorage2Inven(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SAskStorage2Inven(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read ask storage to inven",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	reply := func(result byte) {
+		_ = player.Send(messages.NewMsgS2CStorage2Inven(player.PcId, msg.StorageSlot, msg.InventorySlot, result).GetBytes())
+	}
+	storage := player.storage
+	if storage == nil {
+		reply(itemResultFailure)
+		return
+	}
+
+	index := findInventorySlot(storage.items, msg.StorageSlot)
+	if index < 0 || msg.InventorySlot >= inventorySize || findInventorySlot(player.Inventory, msg.InventorySlot) >= 0 {
+		reply(itemResultFailure)
+		return
+	}
+
+	change := newStorageChange(player, storage)
+	item := change.items[index]
+	item.Slot = msg.InventorySlot
+	change.items = slices.Delete(change.items, index, index+1)
+	change.inventory = append(change.inventory, item)
+	z.commitStorage(player, change, reply)
+}
+
+func (z *Zone) handleAskMoveItemInStorage(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SAskMoveItemInStorage(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read ask move item in storage",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	reply := func(result byte) {
+		_ = player.Send(messages.NewMsgS2CMoveItemInStorage(player.PcId, msg.FromSlot, msg.ToSlot, result).GetBytes())
+	}
+	storage := player.storage
+	if storage == nil {
+		reply(itemResultFailure)
+		return
+	}
+
+	change := newStorageChange(player, storage)
+	if !moveInventoryItem(change.items, msg.FromSlot, msg.ToSlot, storageSize) {
+		reply(itemResultFailure)
+		return
+	}
+
+	z.commitStorage(player, change, reply)
+}
+
+func (z *Zone) handleAskDepositeMoney(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SAskDepositeMoney(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read ask deposite money",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	reply := func(result byte) {
+		var storageWoonz uint32
+		if player.storage != nil {
+			storageWoonz = player.storage.woonz
+		}
+
+		_ = player.Send(messages.NewMsgS2CDepositeMoney(player.PcId, player.Woonz, storageWoonz, result).GetBytes())
+	}
+	storage := player.storage
+	if storage == nil || msg.Woonz == 0 || msg.Woonz > player.Woonz || storage.woonz > math.MaxUint32-msg.Woonz {
+		reply(itemResultFailure)
+		return
+	}
+
+	change := newStorageChange(player, storage)
+	change.woonz -= msg.Woonz
+	change.storageWoonz += msg.Woonz
+	z.commitStorage(player, change, reply)
+}
+
+func (z *Zone) handleAskWithdrawMoney(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SAskWithdrawMoney(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read ask withdraw money",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	reply := func(result byte) {
+		var storageWoonz uint32
+		if player.storage != nil {
+			storageWoonz = player.storage.woonz
+		}
+
+		_ = player.Send(messages.NewMsgS2CWithdrawMoney(player.PcId, player.Woonz, storageWoonz, result).GetBytes())
+	}
+	storage := player.storage
+	if storage == nil || msg.Woonz == 0 || msg.Woonz > storage.woonz || player.Woonz > math.MaxUint32-msg.Woonz {
+		reply(itemResultFailure)
+		return
+	}
+
+	change := newStorageChange(player, storage)
+	change.storageWoonz -= msg.Woonz
+	change.woonz += msg.Woonz
+	z.commitStorage(player, change, reply)
+}
+
+func (z *Zone) handleAskCloseStorage(player *Player, packet []byte) {
+	if _, err := messages.ReadMsgC2SAskCloseStorage(packet); err != nil {
+		z.logger.Error(
+			"Failed to read ask close storage",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	z.closeStorage(player)
+}
+
+// commitStorage saves the character and the storage as they are after the
+// change in one transaction and only applies the change once it has gone
+// through, so an item or Woonz is never in both places or in neither.
+// Regular saves of the player are held back meanwhile, as for a trade. reply
+// is called with the result on the zone goroutine.
+func (z *Zone) commitStorage(player *Player, change storageChange, reply func(byte)) {
+	storage := player.storage
+	storage.busy = true
+	if player.save.saving {
+		z.After(storageCommitRetryDelay, func() {
+			if player.storage != storage {
+				reply(itemResultFailure)
+				return
+			}
+
+			z.commitStorage(player, change, reply)
+		})
+		return
+	}
+
+	player.save.saving = true
+	player.save.dirty = false
+	snapshot := *player
+	snapshot.Inventory = change.inventory
+	snapshot.Woonz = change.woonz
+	characterSave := db.CharacterSave{
+		CharacterId: player.CharacterId,
+		Version:     player.CharacterVersion,
+		Level:       player.Level,
+		Exp:         player.Exp,
+		Data:        z.newCharacterData(&snapshot),
+	}
+	storageSave := db.StorageSave{
+		AccountId:   player.PcId,
+		CharacterId: player.CharacterId,
+		Version:     storage.version,
+		Items:       make(db.StorageItems, len(change.items)),
+		Woonz:       change.storageWoonz,
+	}
+	for i, item := range change.items {
+		storageSave.Items[i] = db.InventoryItem{
+			ItemCode:       item.ItemCode,
+			ItemOption:     item.ItemOption,
+			ItemUniqueCode: item.ItemUniqueCode,
+			Slot:           item.Slot,
+		}
+	}
+
+	go func() {
+		characterVersion, storageVersion, err := z.db.SaveCharacterAndStorage(characterSave, storageSave)
+		z.postSaveResult(player, func(zone *Zone) {
+			storage.busy = false
+			if err == nil {
+				player.Inventory = change.inventory
+				player.Woonz = change.woonz
+				storage.items = change.items
+				storage.woonz = change.storageWoonz
+				storage.version = storageVersion
+			} else if errors.Is(err, db.ErrStorageVersionConflict) && player.storage == storage {
+				z.logger.Error(
+					"Storage save rejected, closing storage",
+					shared.Field{Key: "mapId", Value: z.mapId},
+					shared.Field{Key: "pcId", Value: player.PcId},
+					shared.Field{Key: "version", Value: storage.version},
+				)
+				z.closeStorage(player)
+			}
+
+			zone.finishPlayerSave(player, characterVersion, err)
+			if err != nil {
+				reply(itemResultFailure)
+				return
+			}
+
+			reply(itemResultSuccess)
+		})
+	}()
+}
+
+// closeStorage closes the player's storage and gives up the lock on it so
+// another character of the account can open it.
+func (z *Zone) closeStorage(player *Player) {
+	if player.storage == nil {
+		return
+	}
+
+	player.storage = nil
+	z.releaseStorage(player.PcId, player.CharacterId)
+}
+
+func (z *Zone) releaseStorage(accountId uint32, characterId uint32) {
+	go func() {
+		if err := z.db.CloseStorage(accountId, characterId); err != nil {
+			z.logger.Error(
+				"Failed to close storage",
+				shared.Field{Key: "error", Value: err},
+				shared.Field{Key: "mapId", Value: z.mapId},
+				shared.Field{Key: "pcId", Value: accountId},
+			)
+		}
+	}()
+}
+
+// isNearStorageKeeper reports whether the NPC is a townsperson close enough
+// to the player to open the storage at.
+func (z *Zone) isNearStorageKeeper(player *Player, npcId uint32) bool {
+	npc, exists := z.npcs[npcId]
+	return exists && !npc.IsMonster() &&
+		cellDistance(player.Location.X, player.Location.Y, npc.Location.X, npc.Location.Y) <= storageRange
+}
+
+func newOpenStorageMsg(pcId uint32, storage *playerStorage) *messages.MsgS2COpenStorage {
+	msg := messages.NewMsgS2COpenStorage(pcId, storage.woonz, itemResultSuccess)
+	for i, item := range storage.items {
+		if i >= len(msg.StorageItems) {
+			break
+		}
+
+		msg.StorageItems[i] = messages.CharacterInventory{
+			Item: messages.Item{
+				ItemCode:       item.ItemCode,
+				ItemOption:     item.ItemOption,
+				ItemUniqueCode: item.ItemUniqueCode,
+			},
+			Slot: uint32(item.Slot),
+		}
+	}
+
+	return msg
+}
@@ -0,0 +1,448 @@
+package zoneserver
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages"
+	"github.com/project-agonyl/open-agonyl-servers/internal/zoneserver/db"
+)
+
+const (
+	tradeRange            = 6
+	tradeMaxItems         = 8
+	tradeRequestTimeout   = 30 * time.Second
+	tradeCommitRetryDelay = 100 * time.Millisecond
+)
+
+const (
+	dealResultSuccess   byte = 0x00
+	dealResultRefused   byte = 0x01
+	dealResultCancelled byte = 0x02
+	dealResultFailed    byte = 0x03
+)
+
+type tradeOffer struct {
+	pcId      uint32
+	slots     []byte
+	woonz     uint32
+	decided   bool
+	confirmed bool
+}
+
+// trade is a trade window between two players in the zone. The first offer
+// belongs to the player that asked for the trade. Until the other player
+// accepts, the trade is only an invite and neither player is held by it.
+type trade struct {
+	offers     [2]*tradeOffer
+	committing bool
+}
+
+func (t *trade) offer(pcId uint32) *tradeOffer {
+	if t.offers[0].pcId == pcId {
+		return t.offers[0]
+	}
+
+	return t.offers[1]
+}
+
+func (t *trade) bothDecided() bool {
+	return t.offers[0].decided && t.offers[1].decided
+}
+
+func (z *Zone) handleAskDeal(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SAskDeal(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read ask deal",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	target := z.getTradePartner(player, msg.TargetId)
+	if target == nil || !z.canStartTrade(player, target) || z.tradeInvites[target.PcId] != nil {
+		_ = player.Send(messages.NewMsgS2CAnsDeal(player.PcId, msg.TargetId, dealResultFailed).GetBytes())
+		return
+	}
+
+	t := &trade{
+		offers: [2]*tradeOffer{{pcId: player.PcId}, {pcId: target.PcId}},
+	}
+	z.tradeInvites[target.PcId] = t
+	_ = target.Send(messages.NewMsgS2CAskDeal(target.PcId, player.PcId, player.CharacterName).GetBytes())
+	z.After(tradeRequestTimeout, func() {
+		if z.tradeInvites[target.PcId] == t {
+			z.endTradeInvite(t, dealResultCancelled)
+		}
+	})
+}
+
+func (z *Zone) handleAnsDeal(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SAnsDeal(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read ans deal",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	t := z.tradeInvites[player.PcId]
+	if t == nil || t.offers[0].pcId != msg.RequesterId {
+		return
+	}
+
+	requester := z.getTradePartner(player, msg.RequesterId)
+	if msg.Accept == 0 || requester == nil || !z.canStartTrade(requester, player) {
+		if requester != nil {
+			_ = requester.Send(messages.NewMsgS2CAnsDeal(requester.PcId, player.PcId, dealResultRefused).GetBytes())
+		}
+
+		z.endTradeInvite(t, dealResultRefused)
+		return
+	}
+
+	delete(z.tradeInvites, player.PcId)
+	z.trades[requester.PcId] = t
+	z.trades[player.PcId] = t
+	_ = requester.Send(messages.NewMsgS2CAnsDeal(requester.PcId, player.PcId, dealResultSuccess).GetBytes())
+	_ = player.Send(messages.NewMsgS2CAnsDeal(player.PcId, requester.PcId, dealResultSuccess).GetBytes())
+}
+
+func (z *Zone) handlePutInItem(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SPutInItem(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read put in item",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	t := z.getOpenTrade(player)
+	if t == nil {
+		return
+	}
+
+	offer := t.offer(player.PcId)
+	index := findInventorySlot(player.Inventory, msg.Slot)
+	if index < 0 || len(offer.slots) >= tradeMaxItems || slices.Contains(offer.slots, msg.Slot) {
+		return
+	}
+
+	offer.slots = append(offer.slots, msg.Slot)
+	item := player.Inventory[index]
+	z.sendToTrade(t, messages.NewMsgS2CPutInItem(
+		0,
+		player.PcId,
+		msg.Slot,
+		item.ItemCode,
+		item.ItemOption,
+		item.ItemUniqueCode,
+	).GetBytes())
+}
+
+func (z *Zone) handlePutOutItem(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SPutOutItem(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read put out item",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	t := z.getOpenTrade(player)
+	if t == nil {
+		return
+	}
+
+	offer := t.offer(player.PcId)
+	index := slices.Index(offer.slots, msg.Slot)
+	if index < 0 {
+		return
+	}
+
+	offer.slots = slices.Delete(offer.slots, index, index+1)
+	z.sendToTrade(t, messages.NewMsgS2CPutOutItem(0, player.PcId, msg.Slot).GetBytes())
+}
+
+// handleDecideDeal locks the player's offer together with the Woonz the
+// player puts in.
+func (z *Zone) handleDecideDeal(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SDecideDeal(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read decide deal",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	t := z.trades[player.PcId]
+	if t == nil || t.committing {
+		return
+	}
+
+	offer := t.offer(player.PcId)
+	if offer.decided || msg.Woonz > player.Woonz {
+		return
+	}
+
+	offer.woonz = msg.Woonz
+	offer.decided = true
+	z.sendToTrade(t, messages.NewMsgS2CDecideDeal(0, player.PcId, msg.Woonz).GetBytes())
+}
+
+func (z *Zone) handleConfirmDeal(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SConfirmDeal(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read confirm deal",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	t := z.trades[player.PcId]
+	if t == nil || t.committing {
+		return
+	}
+
+	if msg.Confirm == 0 {
+		z.endTrade(t, dealResultCancelled)
+		return
+	}
+
+	if !t.bothDecided() {
+		return
+	}
+
+	t.offer(player.PcId).confirmed = true
+	if t.offers[0].confirmed && t.offers[1].confirmed {
+		z.commitTrade(t)
+	}
+}
+
+// commitTrade swaps the offers of both players. Both characters are written
+// with the result of the trade in one transaction and the players are only
+// changed once it has gone through, so the trade either happens in full or
+// not at all. Regular saves of both players are held back meanwhile.
+func (z *Zone) commitTrade(t *trade) {
+	t.committing = true
+	players, ok := z.getTradePlayers(t)
+	if !ok {
+		z.endTrade(t, dealResultFailed)
+		return
+	}
+
+	if players[0].save.saving || players[1].save.saving {
+		z.After(tradeCommitRetryDelay, func() {
+			if z.trades[t.offers[0].pcId] == t {
+				z.commitTrade(t)
+			}
+		})
+		return
+	}
+
+	inventories, woonz, ok := newTradeResult(t, players)
+	if !ok {
+		z.endTrade(t, dealResultFailed)
+		return
+	}
+
+	saves := make([]db.CharacterSave, len(players))
+	for i, player := range players {
+		player.save.saving = true
+		player.save.dirty = false
+		snapshot := *player
+		snapshot.Inventory = inventories[i]
+		snapshot.Woonz = woonz[i]
+		saves[i] = db.CharacterSave{
+			CharacterId: player.CharacterId,
+			Version:     player.CharacterVersion,
+			Level:       player.Level,
+			Exp:         player.Exp,
+			Data:        z.newCharacterData(&snapshot),
+		}
+	}
+
+	go func() {
+		versions, err := z.db.SaveCharacters(saves)
+		z.postSaveResult(players[0], func(zone *Zone) {
+			for i, player := range players {
+				if err != nil {
+					zone.finishPlayerSave(player, 0, err)
+					continue
+				}
+
+				player.Inventory = inventories[i]
+				player.Woonz = woonz[i]
+				zone.finishPlayerSave(player, versions[i], nil)
+			}
+
+			if err != nil {
+				zone.endTrade(t, dealResultFailed)
+				return
+			}
+
+			zone.endTrade(t, dealResultSuccess)
+		})
+	}()
+}
+
+// newTradeResult works out the inventories and Woonz of both players after
+// the trade, or reports false if the trade cannot be made.
+func newTradeResult(t *trade, players [2]*Player) ([2][]InventoryItem, [2]uint32, bool) {
+	var inventories [2][]InventoryItem
+	var woonz [2]uint32
+	var given [2][]InventoryItem
+	for i, player := range players {
+		offer := t.offers[i]
+		if offer.woonz > player.Woonz {
+			return inventories, woonz, false
+		}
+
+		inventories[i] = make([]InventoryItem, 0, len(player.Inventory))
+		for _, item := range player.Inventory {
+			if slices.Contains(offer.slots, item.Slot) {
+				given[i] = append(given[i], item)
+				continue
+			}
+
+			inventories[i] = append(inventories[i], item)
+		}
+
+		if len(given[i]) != len(offer.slots) {
+			return inventories, woonz, false
+		}
+	}
+
+	for i, player := range players {
+		other := 1 - i
+		received := t.offers[other].woonz
+		if player.Woonz-t.offers[i].woonz > math.MaxUint32-received {
+			return inventories, woonz, false
+		}
+
+		woonz[i] = player.Woonz - t.offers[i].woonz + received
+		for _, item := range given[other] {
+			slot, ok := freeInventorySlot(inventories[i])
+			if !ok {
+				return inventories, woonz, false
+			}
+
+			item.Slot = slot
+			inventories[i] = append(inventories[i], item)
+		}
+	}
+
+	return inventories, woonz, true
+}
+
+// cancelPlayerTrade cancels the trade of a player that is leaving, and the
+// invite the player was asked to. A trade that is being committed is left to
+// finish. Invites the player sent fail once they are answered.
+func (z *Zone) cancelPlayerTrade(player *Player) {
+	if invite := z.tradeInvites[player.PcId]; invite != nil {
+		z.endTradeInvite(invite, dealResultCancelled)
+	}
+
+	t := z.trades[player.PcId]
+	if t == nil || t.committing {
+		return
+	}
+
+	z.endTrade(t, dealResultCancelled)
+}
+
+// canStartTrade reports whether neither player is busy with a trade or their
+// storage.
+func (z *Zone) canStartTrade(player *Player, partner *Player) bool {
+	return z.trades[player.PcId] == nil && z.trades[partner.PcId] == nil &&
+		player.storage == nil && partner.storage == nil
+}
+
+// endTradeInvite drops an invite that was not accepted. Players that are in
+// another trade by now are not told, so that window stays open.
+func (z *Zone) endTradeInvite(t *trade, result byte) {
+	delete(z.tradeInvites, t.offers[1].pcId)
+	packet := messages.NewMsgS2CConfirmDeal(0, result).GetBytes()
+	for _, offer := range t.offers {
+		player, exists := z.players.Get(offer.pcId)
+		if !exists || player.Zone != z || player.GateServerSession == nil || z.trades[offer.pcId] != nil {
+			continue
+		}
+
+		z.relay(player, packet)
+	}
+}
+
+func (z *Zone) endTrade(t *trade, result byte) {
+	for _, offer := range t.offers {
+		delete(z.trades, offer.pcId)
+	}
+
+	z.sendToTrade(t, messages.NewMsgS2CConfirmDeal(0, result).GetBytes())
+}
+
+func (z *Zone) sendToTrade(t *trade, packet []byte) {
+	for _, offer := range t.offers {
+		player, exists := z.players.Get(offer.pcId)
+		if !exists || player.Zone != z || player.GateServerSession == nil {
+			continue
+		}
+
+		z.relay(player, packet)
+	}
+}
+
+// getOpenTrade returns the trade of the player if its offer can still be
+// changed.
+func (z *Zone) getOpenTrade(player *Player) *trade {
+	t := z.trades[player.PcId]
+	if t == nil || t.committing || t.offers[0].decided || t.offers[1].decided {
+		return nil
+	}
+
+	return t
+}
+
+// getTradePartner returns the player with partnerId if it can trade with the
+// player.
+func (z *Zone) getTradePartner(player *Player, partnerId uint32) *Player {
+	if partnerId == player.PcId {
+		return nil
+	}
+
+	partner, exists := z.players.Get(partnerId)
+	if !exists || partner.Zone != z || partner.State != PlayerStateInGame ||
+		cellDistance(player.Location.X, player.Location.Y, partner.Location.X, partner.Location.Y) > tradeRange {
+		return nil
+	}
+
+	return partner
+}
+
+func (z *Zone) getTradePlayers(t *trade) ([2]*Player, bool) {
+	var players [2]*Player
+	for i, offer := range t.offers {
+		player, exists := z.players.Get(offer.pcId)
+		if !exists || player.Zone != z || player.State != PlayerStateInGame {
+			return players, false
+		}
+
+		players[i] = player
+	}
+
+	return players, z.getTradePartner(players[0], players[1].PcId) != nil
+}
@@ -1,29 +1,54 @@
 package zoneserver
 
 import (
-	"strconv"
+	"encoding/binary"
+	"slices"
 	"sync/atomic"
+	"time"
 
 	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
 	"github.com/project-agonyl/open-agonyl-servers/internal/shared/data"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages/protocol"
+	"github.com/project-agonyl/open-agonyl-servers/internal/utils"
 	"github.com/project-agonyl/open-agonyl-servers/internal/zoneserver/config"
 	"github.com/project-agonyl/open-agonyl-servers/internal/zoneserver/db"
+	"github.com/project-agonyl/open-agonyl-servers/internal/zoneserver/pathfinding"
+)
+
+const (
+	pathfindingMaxNodes  = 4096
+	pathfindingCacheSize = 512
 )
 
 type Zone struct {
-	serverId              byte
-	mapId                 uint16
-	players               *Players
-	currentPlayers        []uint32
-	logger                shared.Logger
-	cfg                   *config.EnvVars
-	db                    db.DBService
-	zoneManager           *ZoneManager
-	mapData               *data.MapData
-	isRunning             atomic.Bool
-	playerPacketQueue     *shared.SafeQueue[[]byte]
-	mainServerPacketQueue *shared.SafeQueue[[]byte]
-	playerLoginQueue      *shared.SafeQueue[uint32]
+	serverId               byte
+	mapId                  uint16
+	players                *Players
+	currentPlayers         []uint32
+	logger                 shared.Logger
+	cfg                    *config.EnvVars
+	db                     db.DBService
+	zoneManager            *ZoneManager
+	mapData                *data.MapData
+	grid                   *Grid
+	pathfinder             *pathfinding.Pathfinder
+	npcs                   map[uint32]*NPC
+	npcUidGenerator        *shared.UidGenerator
+	groundItems            map[uint32]*GroundItem
+	groundItemCells        map[uint32]uint32
+	groundItemUidGenerator *shared.UidGenerator
+	trades                 map[uint32]*trade
+	tradeInvites           map[uint32]*trade
+	isRunning              atomic.Bool
+	playerPacketQueue      *shared.SafeQueue[[]byte]
+	mainServerPacketQueue  *shared.SafeQueue[[]byte]
+	playerLoginQueue       *shared.SafeQueue[uint32]
+	playerLogoutQueue      *shared.SafeQueue[uint32]
+	taskQueue              *shared.SafeQueue[func()]
+	tickInterval           time.Duration
+	tickCount              uint64
+	tickMonitor            *utils.PerformanceMonitor
+	timers                 zoneTimers
 }
 
 func NewZone(
@@ -34,32 +59,53 @@ func NewZone(
 	players *Players,
 	zoneManager *ZoneManager,
 ) (*Zone, error) {
-	mapData, err := data.LoadMapData(cfg.ZoneDataMapPath + "/" + strconv.Itoa(int(mapId)))
+	mapData, err := zoneManager.GetMapData(mapId)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Zone{
-		serverId:              cfg.ServerId,
-		mapId:                 mapId,
-		players:               players,
-		currentPlayers:        make([]uint32, 0),
-		logger:                logger,
-		cfg:                   cfg,
-		db:                    db,
-		zoneManager:           zoneManager,
-		mapData:               mapData,
-		playerPacketQueue:     shared.NewSafeQueue[[]byte](4096),
-		mainServerPacketQueue: shared.NewSafeQueue[[]byte](4096),
-		playerLoginQueue:      shared.NewSafeQueue[uint32](4096),
-	}, nil
+	zone := &Zone{
+		serverId:               cfg.ServerId,
+		mapId:                  mapId,
+		players:                players,
+		currentPlayers:         make([]uint32, 0),
+		logger:                 logger,
+		cfg:                    cfg,
+		db:                     db,
+		zoneManager:            zoneManager,
+		mapData:                mapData,
+		grid:                   NewGrid(),
+		pathfinder:             pathfinding.NewPathfinder(pathfinding.NewMapGrid(mapData), pathfindingMaxNodes, pathfindingCacheSize),
+		npcs:                   make(map[uint32]*NPC),
+		npcUidGenerator:        shared.NewUidGenerator(npcUidStart),
+		groundItems:            make(map[uint32]*GroundItem),
+		groundItemCells:        make(map[uint32]uint32),
+		groundItemUidGenerator: shared.NewUidGenerator(groundItemUidStart),
+		trades:                 make(map[uint32]*trade),
+		tradeInvites:           make(map[uint32]*trade),
+		playerPacketQueue:      shared.NewSafeQueue[[]byte](4096),
+		mainServerPacketQueue:  shared.NewSafeQueue[[]byte](4096),
+		playerLoginQueue:       shared.NewSafeQueue[uint32](4096),
+		playerLogoutQueue:      shared.NewSafeQueue[uint32](4096),
+		taskQueue:              shared.NewSafeQueue[func()](4096),
+		tickInterval:           time.Second / time.Duration(cfg.ZoneTickRate),
+		tickMonitor:            utils.NewPerformanceMonitor(),
+		timers:                 make(zoneTimers, 0),
+	}
+	zone.loadNPCs()
+	return zone, nil
 }
 
 func (z *Zone) Start() error {
 	z.logger.Info("Starting zone", shared.Field{Key: "mapId", Value: z.mapId})
 	z.isRunning.Store(true)
+	z.After(z.saveInterval(), z.flushDirtyPlayers)
+	z.After(partyUpdateInterval, z.sendPartyUpdates)
+	ticker := time.NewTicker(z.tickInterval)
+	defer ticker.Stop()
 	for z.isRunning.Load() {
-		// TODO: game loop
+		now := <-ticker.C
+		z.tick(now)
 	}
 
 	z.logger.Info("Zone stopped", shared.Field{Key: "mapId", Value: z.mapId})
@@ -78,6 +124,319 @@ func (z *Zone) EnqueuePlayerLogin(pcId uint32) bool {
 	return z.playerLoginQueue.Enqueue(pcId)
 }
 
+// Post queues task to run on the zone goroutine during the next tick. It is
+// how work finished on other goroutines hands its results back to the zone.
+func (z *Zone) Post(task func()) bool {
+	return z.taskQueue.Enqueue(task)
+}
+
+func (z *Zone) EnqueuePlayerLogout(pcId uint32) bool {
+	return z.playerLogoutQueue.Enqueue(pcId)
+}
+
 func (z *Zone) Stop() {
 	z.isRunning.Store(false)
 }
+
+// After schedules callback to run on the zone goroutine once d has elapsed.
+// It must only be called from the zone goroutine.
+func (z *Zone) After(d time.Duration, callback func()) {
+	z.timers.schedule(time.Now().Add(d), callback)
+}
+
+func (z *Zone) tick(now time.Time) {
+	z.tickMonitor.Reset()
+	z.tickMonitor.Start()
+	z.tickCount++
+	z.processPlayerLogins()
+	z.processPlayerPackets()
+	z.processPlayerLogouts()
+	z.processMainServerPackets()
+	z.processTasks()
+	z.timers.advance(now)
+	z.updateMonsters(now)
+	z.tickMonitor.Stop()
+	elapsed := z.tickMonitor.ElapsedMilliseconds()
+	if elapsed > float64(z.tickInterval.Milliseconds()) {
+		z.logger.Warn(
+			"Zone tick overrun",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "tick", Value: z.tickCount},
+			shared.Field{Key: "elapsedMs", Value: elapsed},
+			shared.Field{Key: "tickIntervalMs", Value: z.tickInterval.Milliseconds()},
+		)
+	}
+}
+
+func (z *Zone) processPlayerLogins() {
+	for {
+		pcId, ok := z.playerLoginQueue.Dequeue()
+		if !ok {
+			return
+		}
+
+		player, exists := z.players.Get(pcId)
+		if !exists {
+			z.logger.Error(
+				"Could not find player for world login",
+				shared.Field{Key: "mapId", Value: z.mapId},
+				shared.Field{Key: "pcId", Value: pcId},
+			)
+			continue
+		}
+
+		z.handlePlayerLogin(player)
+	}
+}
+
+func (z *Zone) processPlayerLogouts() {
+	for {
+		pcId, ok := z.playerLogoutQueue.Dequeue()
+		if !ok {
+			return
+		}
+
+		player, exists := z.players.Get(pcId)
+		if !exists || player.Zone != z {
+			continue
+		}
+
+		z.handlePlayerLogout(player)
+	}
+}
+
+func (z *Zone) processPlayerPackets() {
+	for {
+		packet, ok := z.playerPacketQueue.Dequeue()
+		if !ok {
+			return
+		}
+
+		z.handlePlayerPacket(packet)
+	}
+}
+
+func (z *Zone) processMainServerPackets() {
+	for {
+		packet, ok := z.mainServerPacketQueue.Dequeue()
+		if !ok {
+			return
+		}
+
+		z.handleMainServerPacket(packet)
+	}
+}
+
+func (z *Zone) processTasks() {
+	for {
+		task, ok := z.taskQueue.Dequeue()
+		if !ok {
+			return
+		}
+
+		task()
+	}
+}
+
+func (z *Zone) handlePlayerPacket(packet []byte) {
+	if len(packet) < 12 {
+		z.logger.Error(
+			"Player packet too short",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "packet", Value: packet},
+		)
+		return
+	}
+
+	pcId := binary.LittleEndian.Uint32(packet[4:])
+	proto := binary.LittleEndian.Uint16(packet[10:])
+	player, exists := z.players.Get(pcId)
+	if !exists || player.Zone != z {
+		z.logger.Error(
+			"Could not find player in zone",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "pcId", Value: pcId},
+			shared.Field{Key: "protocol", Value: proto},
+		)
+		return
+	}
+
+	if player.State != PlayerStateInGame {
+		z.logger.Debug(
+			"Dropping packet from player not in game",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "pcId", Value: pcId},
+			shared.Field{Key: "protocol", Value: proto},
+		)
+		return
+	}
+
+	if slices.Contains(inventoryProtocols, proto) && z.isInventoryLocked(player) {
+		z.logger.Debug(
+			"Dropping packet while inventory is locked",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "pcId", Value: pcId},
+			shared.Field{Key: "protocol", Value: proto},
+		)
+		return
+	}
+
+	switch proto {
+	case protocol.C2SAskMove:
+		z.handleAskMove(player, packet)
+	case protocol.C2SPcMove:
+		z.handlePcMove(player, packet)
+	case protocol.C2SAskHsMove:
+		z.handleAskHsMove(player, packet)
+	case protocol.C2SHsMove:
+		z.handleHsMove(player, packet)
+	case protocol.C2SAskAttack:
+		z.handleAskAttack(player, packet)
+	case protocol.C2SHsAskAttack:
+		z.handleHsAskAttack(player, packet)
+	case protocol.C2SWarp:
+		z.handleWarp(player, packet)
+	case protocol.C2SAskWarpZ2B:
+		z.handleAskWarpZ2B(player, packet)
+	case protocol.C2SAskWarpB2Z:
+		z.handleAskWarpB2Z(player, packet)
+	case protocol.C2SSay:
+		z.handleSay(player, packet)
+	case protocol.C2SChatWindowOpt:
+		z.handleChatWindowOpt(player, packet)
+	case protocol.C2SPickupItem:
+		z.handlePickupItem(player, packet)
+	case protocol.C2SDropItem:
+		z.handleDropItem(player, packet)
+	case protocol.C2SBuyItem:
+		z.handleBuyItem(player, packet)
+	case protocol.C2SSellItem:
+		z.handleSellItem(player, packet)
+	case protocol.C2SMoveItem:
+		z.handleMoveItem(player, packet)
+	case protocol.C2SAskDeal:
+		z.handleAskDeal(player, packet)
+	case protocol.C2SAnsDeal:
+		z.handleAnsDeal(player, packet)
+	case protocol.C2SPutInItem:
+		z.handlePutInItem(player, packet)
+	case protocol.C2SPutOutItem:
+		z.handlePutOutItem(player, packet)
+	case protocol.C2SDecideDeal:
+		z.handleDecideDeal(player, packet)
+	case protocol.C2SConfirmDeal:
+		z.handleConfirmDeal(player, packet)
+	case protocol.C2SWearItem:
+		z.handleWearItem(player, packet)
+	case protocol.C2SStripItem:
+		z.handleStripItem(player, packet)
+	case protocol.C2SHsWearItem:
+		z.handleHsWearItem(player, packet)
+	case protocol.C2SHsStripItem:
+		z.handleHsStripItem(player, packet)
+	case protocol.C2SAllotPoint:
+		z.handleAllotPoint(player, packet)
+	case protocol.C2SRetrievePoint:
+		z.handleRetrievePoint(player, packet)
+	case protocol.C2SHsAllotPoint:
+		z.handleHsAllotPoint(player, packet)
+	case protocol.C2SHsRetrievePoint:
+		z.handleHsRetrievePoint(player, packet)
+	case protocol.C2SLearnSkill:
+		z.handleLearnSkill(player, packet)
+	case protocol.C2SAskSkill:
+		z.handleAskSkill(player, packet)
+	case protocol.C2SSkillSlotInfo:
+		z.handleSkillSlotInfo(player, packet)
+	case protocol.C2SLearnPskill:
+		z.handleLearnPskill(player, packet)
+	case protocol.C2SForgetAllPskill:
+		z.handleForgetAllPskill(player, packet)
+	case protocol.C2SUsePotion:
+		z.handleUsePotion(player, packet)
+	case protocol.C2SUsePotionEx:
+		z.handleUsePotionEx(player, packet)
+	case protocol.C2SUseItem:
+		z.handleUseItem(player, packet)
+	case protocol.C2SAskOpenStorage:
+		z.handleAskOpenStorage(player, packet)
+	case protocol.C2SAskInven2Storage:
+		z.handleAskInven2Storage(player, packet)
+	case protocol.C2SAskStorage2Inven:
+		z.handleAskStorage2Inven(player, packet)
+	case protocol.C2SAskMoveItemInStorage:
+		z.handleAskMoveItemInStorage(player, packet)
+	case protocol.C2SAskDepositeMoney:
+		z.handleAskDepositeMoney(player, packet)
+	case protocol.C2SAskWithdrawMoney:
+		z.handleAskWithdrawMoney(player, packet)
+	case protocol.C2SAskCloseStorage:
+		z.handleAskCloseStorage(player, packet)
+	case protocol.C2SAskParty:
+		z.handleAskParty(player, packet)
+	case protocol.C2SAnsParty:
+		z.handleAnsParty(player, packet)
+	case protocol.C2SOutParty:
+		z.handleOutParty(player, packet)
+	case protocol.C2SClan:
+		z.handleClan(player, packet)
+	case protocol.C2SJoinClan:
+		z.handleJoinClan(player, packet)
+	case protocol.C2SAnsClan:
+		z.handleAnsClan(player, packet)
+	case protocol.C2SBoltClan:
+		z.handleBoltClan(player, packet)
+	case protocol.C2SReqClanInfo:
+		z.handleReqClanInfo(player, packet)
+	default:
+		z.logger.Debug(
+			"Unhandled player packet",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "pcId", Value: pcId},
+			shared.Field{Key: "protocol", Value: proto},
+		)
+	}
+}
+
+func (z *Zone) handleMainServerPacket(packet []byte) {
+	if len(packet) < 9 {
+		z.logger.Error(
+			"Main server packet too short",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "packet", Value: packet},
+		)
+		return
+	}
+
+	proto := binary.LittleEndian.Uint16(packet)
+	pcId := binary.LittleEndian.Uint32(packet[4:])
+	switch proto {
+	case protocol.M2SError:
+		z.handleMainServerError(packet)
+	case protocol.M2SZoneChange:
+		z.handleZoneChange(packet)
+	case protocol.M2SWhisper:
+		z.handleWhisper(packet)
+	case protocol.M2SAskParty:
+		z.handleMainServerAskParty(packet)
+	case protocol.M2SAnsParty:
+		z.handleMainServerAnsParty(packet)
+	case protocol.M2SPartyInfo:
+		z.handlePartyInfo(packet)
+	case protocol.M2SPartyMemberUpdate:
+		z.handlePartyMemberUpdate(packet)
+	case protocol.M2SJoinClan:
+		z.handleMainServerJoinClan(packet)
+	case protocol.M2SAnsClan:
+		z.handleMainServerAnsClan(packet)
+	case protocol.M2SClanUpdate:
+		z.handleClanUpdate(packet)
+	default:
+		z.logger.Debug(
+			"Unhandled main server packet",
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "pcId", Value: pcId},
+			shared.Field{Key: "protocol", Value: proto},
+		)
+	}
+}
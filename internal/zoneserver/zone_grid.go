@@ -0,0 +1,172 @@
+package zoneserver
+
+const (
+	gridSectorSize  = 16
+	gridSectorCount = (0xFF + gridSectorSize - 1) / gridSectorSize
+	gridViewRange   = 1
+)
+
+type gridSector struct {
+	x       int
+	y       int
+	players map[uint32]*Player
+	npcs    map[uint32]*NPC
+	items   map[uint32]*GroundItem
+}
+
+// Grid is a sector based area-of-interest index over the map cells. Anything
+// within gridViewRange sectors of an object is visible to it. It is not
+// goroutine-safe and must only be touched from the zone goroutine.
+type Grid struct {
+	sectors [gridSectorCount][gridSectorCount]*gridSector
+}
+
+func NewGrid() *Grid {
+	grid := &Grid{}
+	for x := 0; x < gridSectorCount; x++ {
+		for y := 0; y < gridSectorCount; y++ {
+			grid.sectors[x][y] = &gridSector{
+				x:       x,
+				y:       y,
+				players: make(map[uint32]*Player),
+				npcs:    make(map[uint32]*NPC),
+				items:   make(map[uint32]*GroundItem),
+			}
+		}
+	}
+
+	return grid
+}
+
+func (g *Grid) AddPlayer(player *Player) {
+	g.sectorAt(player.Location.X, player.Location.Y).players[player.PcId] = player
+}
+
+func (g *Grid) RemovePlayer(player *Player) {
+	delete(g.sectorAt(player.Location.X, player.Location.Y).players, player.PcId)
+}
+
+// MovePlayer updates the player location and returns the sectors that went
+// out of view and came into view as a result of the move.
+func (g *Grid) MovePlayer(player *Player, x byte, y byte) ([]*gridSector, []*gridSector) {
+	from := g.sectorAt(player.Location.X, player.Location.Y)
+	to := g.sectorAt(x, y)
+	player.Location.X = x
+	player.Location.Y = y
+	if from == to {
+		return nil, nil
+	}
+
+	delete(from.players, player.PcId)
+	to.players[player.PcId] = player
+	return g.diff(from, to)
+}
+
+func (g *Grid) AddNPC(npc *NPC) {
+	g.sectorAt(npc.Location.X, npc.Location.Y).npcs[npc.Id] = npc
+}
+
+func (g *Grid) RemoveNPC(npc *NPC) {
+	delete(g.sectorAt(npc.Location.X, npc.Location.Y).npcs, npc.Id)
+}
+
+// MoveNPC updates the NPC location and returns the sectors that went out of
+// view and came into view as a result of the move.
+func (g *Grid) MoveNPC(npc *NPC, x byte, y byte) ([]*gridSector, []*gridSector) {
+	from := g.sectorAt(npc.Location.X, npc.Location.Y)
+	to := g.sectorAt(x, y)
+	npc.Location.X = x
+	npc.Location.Y = y
+	if from == to {
+		return nil, nil
+	}
+
+	delete(from.npcs, npc.Id)
+	to.npcs[npc.Id] = npc
+	return g.diff(from, to)
+}
+
+func (g *Grid) AddItem(item *GroundItem) {
+	g.sectorAt(item.Location.X, item.Location.Y).items[item.Id] = item
+}
+
+func (g *Grid) RemoveItem(item *GroundItem) {
+	delete(g.sectorAt(item.Location.X, item.Location.Y).items, item.Id)
+}
+
+func (g *Grid) ForEachPlayerInRange(x byte, y byte, f func(player *Player) bool) {
+	g.forEachSectorInRange(g.sectorAt(x, y), func(sector *gridSector) bool {
+		for _, player := range sector.players {
+			if !f(player) {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+func (g *Grid) ForEachNPCInRange(x byte, y byte, f func(npc *NPC) bool) {
+	g.forEachSectorInRange(g.sectorAt(x, y), func(sector *gridSector) bool {
+		for _, npc := range sector.npcs {
+			if !f(npc) {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+func (g *Grid) ForEachItemInRange(x byte, y byte, f func(item *GroundItem) bool) {
+	g.forEachSectorInRange(g.sectorAt(x, y), func(sector *gridSector) bool {
+		for _, item := range sector.items {
+			if !f(item) {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+func (g *Grid) sectorAt(x byte, y byte) *gridSector {
+	return g.sectors[int(x)/gridSectorSize][int(y)/gridSectorSize]
+}
+
+func (g *Grid) forEachSectorInRange(center *gridSector, f func(sector *gridSector) bool) {
+	for x := max(center.x-gridViewRange, 0); x <= min(center.x+gridViewRange, gridSectorCount-1); x++ {
+		for y := max(center.y-gridViewRange, 0); y <= min(center.y+gridViewRange, gridSectorCount-1); y++ {
+			if !f(g.sectors[x][y]) {
+				return
+			}
+		}
+	}
+}
+
+func (g *Grid) diff(from *gridSector, to *gridSector) ([]*gridSector, []*gridSector) {
+	left := make([]*gridSector, 0)
+	entered := make([]*gridSector, 0)
+	g.forEachSectorInRange(from, func(sector *gridSector) bool {
+		if !sector.isInRangeOf(to) {
+			left = append(left, sector)
+		}
+
+		return true
+	})
+	g.forEachSectorInRange(to, func(sector *gridSector) bool {
+		if !sector.isInRangeOf(from) {
+			entered = append(entered, sector)
+		}
+
+		return true
+	})
+
+	return left, entered
+}
+
+func (s *gridSector) isInRangeOf(other *gridSector) bool {
+	dx := s.x - other.x
+	dy := s.y - other.y
+	return dx >= -gridViewRange && dx <= gridViewRange && dy >= -gridViewRange && dy <= gridViewRange
+}
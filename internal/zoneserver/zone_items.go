@@ -0,0 +1,276 @@
+package zoneserver
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/data"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages"
+)
+
+// groundItemUidStart keeps ground item ids clear of pcIds and NPC ids.
+const groundItemUidStart uint32 = 0x60000000
+
+const (
+	groundItemDecayTime     = 3 * time.Minute
+	groundItemOwnershipTime = 30 * time.Second
+	groundItemDropRange     = 2
+	pickupRange             = 2
+	itemSerialTimeout       = 5 * time.Second
+)
+
+// GroundItem is an item lying on a map cell. It keeps the unique code it had
+// in the inventory, or the one it was given when it dropped from a monster,
+// so it stays the same item wherever it goes.
+type GroundItem struct {
+	Id             uint32
+	ItemCode       uint32
+	ItemOption     uint32
+	ItemUniqueCode uint32
+	Location       Location
+	OwnerPcId      uint32
+	OwnedUntil     time.Time
+}
+
+func (z *Zone) handleDropItem(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SDropItem(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read drop item",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	index := findInventorySlot(player.Inventory, msg.Slot)
+	if _, _, ok := z.findItemDropCell(player.Location.X, player.Location.Y); index < 0 || !ok {
+		_ = player.Send(messages.NewMsgS2CDropItem(player.PcId, msg.Slot, itemResultFailure).GetBytes())
+		return
+	}
+
+	// The item only reaches the ground once the save without it has gone
+	// through. Until then nobody can pick it up, so a picker's save can never
+	// land before the dropper's and leave the item in both inventories.
+	item := player.Inventory[index]
+	origin := player.Location
+	player.Inventory = slices.Delete(player.Inventory, index, index+1)
+	player.MarkDirty()
+	z.savePlayer(player, func(err error) {
+		x, y, ok := z.findItemDropCell(origin.X, origin.Y)
+		if err != nil || !ok {
+			result := itemResultFailure
+			if !z.returnDroppedItem(player, item) {
+				result = itemResultSuccess
+			}
+
+			_ = player.Send(messages.NewMsgS2CDropItem(player.PcId, msg.Slot, result).GetBytes())
+			return
+		}
+
+		z.placeGroundItem(&GroundItem{
+			ItemCode:       item.ItemCode,
+			ItemOption:     item.ItemOption,
+			ItemUniqueCode: item.ItemUniqueCode,
+		}, x, y)
+		_ = player.Send(messages.NewMsgS2CDropItem(player.PcId, msg.Slot, itemResultSuccess).GetBytes())
+	})
+}
+
+// returnDroppedItem puts an item whose drop did not go through back in the
+// inventory, in its old slot if that is still free. With no room left it is
+// put on the ground by the player instead, owned by them, and false is
+// returned.
+func (z *Zone) returnDroppedItem(player *Player, item InventoryItem) bool {
+	player.MarkDirty()
+	if findInventorySlot(player.Inventory, item.Slot) >= 0 {
+		slot, ok := freeInventorySlot(player.Inventory)
+		if !ok {
+			x, y, ok := z.findItemDropCell(player.Location.X, player.Location.Y)
+			if !ok {
+				x, y = player.Location.X, player.Location.Y
+			}
+
+			z.placeGroundItem(&GroundItem{
+				ItemCode:       item.ItemCode,
+				ItemOption:     item.ItemOption,
+				ItemUniqueCode: item.ItemUniqueCode,
+				OwnerPcId:      player.PcId,
+				OwnedUntil:     time.Now().Add(groundItemOwnershipTime),
+			}, x, y)
+			return false
+		}
+
+		item.Slot = slot
+	}
+
+	player.Inventory = append(player.Inventory, item)
+	return true
+}
+
+func (z *Zone) handlePickupItem(player *Player, packet []byte) {
+	msg, err := messages.ReadMsgC2SPickupItem(packet)
+	if err != nil {
+		z.logger.Error(
+			"Failed to read pickup item",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+		return
+	}
+
+	item, exists := z.groundItems[msg.ItemId]
+	if !exists ||
+		cellDistance(player.Location.X, player.Location.Y, item.Location.X, item.Location.Y) > pickupRange ||
+		!z.canLoot(player, item) {
+		_ = player.Send(messages.NewMsgS2CPickupItem(player.PcId, msg.ItemId, 0, itemResultFailure).GetBytes())
+		return
+	}
+
+	slot, ok := freeInventorySlot(player.Inventory)
+	if !ok {
+		_ = player.Send(messages.NewMsgS2CPickupItem(player.PcId, msg.ItemId, 0, itemResultFailure).GetBytes())
+		return
+	}
+
+	z.removeGroundItem(item)
+	player.Inventory = append(player.Inventory, InventoryItem{
+		ItemCode:       item.ItemCode,
+		ItemOption:     item.ItemOption,
+		ItemUniqueCode: item.ItemUniqueCode,
+		Slot:           slot,
+	})
+	player.MarkDirty()
+	z.savePlayer(player, nil)
+	_ = player.Send(messages.NewMsgS2CPickupItem(player.PcId, msg.ItemId, slot, itemResultSuccess).GetBytes())
+}
+
+// canLoot reports whether the player may pick the item up. Loot belongs to
+// the player it dropped for, and that player's party, until its ownership
+// time runs out.
+func (z *Zone) canLoot(player *Player, item *GroundItem) bool {
+	return item.OwnerPcId == 0 || item.OwnerPcId == player.PcId ||
+		(player.Party != nil && player.Party.HasMember(item.OwnerPcId)) ||
+		!time.Now().Before(item.OwnedUntil)
+}
+
+// dropMonsterLoot rolls the drop table of the monster and puts what dropped
+// around where it died, owned by the killer for a while. The unique codes of
+// the new items are fetched off the zone goroutine.
+func (z *Zone) dropMonsterLoot(npc *NPC, killer *Player) {
+	drops := make([]data.DropItem, 0)
+	for _, drop := range z.zoneManager.GetDropItems(npc.Spawn.Id) {
+		if rand.Uint32N(data.DropChanceScale) < drop.Chance {
+			drops = append(drops, drop)
+		}
+	}
+
+	if len(drops) == 0 {
+		return
+	}
+
+	x, y, ownerPcId := npc.Location.X, npc.Location.Y, killer.PcId
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), itemSerialTimeout)
+		defer cancel()
+		serials, err := z.zoneManager.serialNumberGenerator.GetNextSerials(ctx, len(drops))
+		if err != nil {
+			z.logger.Error(
+				"Failed to get item serials for monster loot",
+				shared.Field{Key: "error", Value: err},
+				shared.Field{Key: "mapId", Value: z.mapId},
+				shared.Field{Key: "npcCode", Value: npc.Spawn.Id},
+			)
+		}
+
+		if len(serials) == 0 {
+			return
+		}
+
+		posted := z.Post(func() {
+			ownedUntil := time.Now().Add(groundItemOwnershipTime)
+			for i, serial := range serials {
+				dropX, dropY, ok := z.findItemDropCell(x, y)
+				if !ok {
+					return
+				}
+
+				z.placeGroundItem(&GroundItem{
+					ItemCode:       drops[i].ItemCode,
+					ItemOption:     drops[i].ItemOption,
+					ItemUniqueCode: serial,
+					OwnerPcId:      ownerPcId,
+					OwnedUntil:     ownedUntil,
+				}, dropX, dropY)
+			}
+		})
+		if !posted {
+			z.logger.Error(
+				"Failed to post monster loot",
+				shared.Field{Key: "mapId", Value: z.mapId},
+				shared.Field{Key: "npcCode", Value: npc.Spawn.Id},
+			)
+		}
+	}()
+}
+
+// placeGroundItem puts the item on the given cell, shows it to the players
+// around and schedules its decay.
+func (z *Zone) placeGroundItem(item *GroundItem, x byte, y byte) {
+	item.Id = z.groundItemUidGenerator.Uid()
+	item.Location = Location{MapId: z.mapId, X: x, Y: y}
+	z.groundItems[item.Id] = item
+	z.groundItemCells[item.Location.Cell()] = item.Id
+	z.grid.AddItem(item)
+	z.BroadcastNearby(x, y, newItemAppearMsg(0, item).GetBytes(), 0)
+	z.After(groundItemDecayTime, func() {
+		if current, exists := z.groundItems[item.Id]; exists && current == item {
+			z.removeGroundItem(item)
+		}
+	})
+}
+
+func (z *Zone) removeGroundItem(item *GroundItem) {
+	delete(z.groundItems, item.Id)
+	if z.groundItemCells[item.Location.Cell()] == item.Id {
+		delete(z.groundItemCells, item.Location.Cell())
+	}
+	z.grid.RemoveItem(item)
+	disappear := messages.NewMsgS2CItemDisappear(0, item.Id).GetBytes()
+	z.BroadcastNearby(item.Location.X, item.Location.Y, disappear, 0)
+}
+
+// findItemDropCell finds the closest walkable cell to x, y without an item
+// on it, at most groundItemDropRange cells away.
+func (z *Zone) findItemDropCell(x byte, y byte) (byte, byte, bool) {
+	for r := 0; r <= groundItemDropRange; r++ {
+		for dx := -r; dx <= r; dx++ {
+			for dy := -r; dy <= r; dy++ {
+				if max(abs(dx), abs(dy)) != r {
+					continue
+				}
+
+				cellX, cellY := int(x)+dx, int(y)+dy
+				if cellX < 0 || cellX > 0xFF || cellY < 0 || cellY > 0xFF {
+					continue
+				}
+
+				location := Location{X: byte(cellX), Y: byte(cellY)}
+				if _, taken := z.groundItemCells[location.Cell()]; taken || !z.pathfinder.IsWalkable(location.X, location.Y) {
+					continue
+				}
+
+				return location.X, location.Y, true
+			}
+		}
+	}
+
+	return 0, 0, false
+}
+
+func newItemAppearMsg(pcId uint32, item *GroundItem) *messages.MsgS2CItemAppear {
+	return messages.NewMsgS2CItemAppear(pcId, item.Id, item.ItemCode, item.ItemOption, item.Location.Cell())
+}
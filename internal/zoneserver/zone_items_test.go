@@ -0,0 +1,95 @@
+package zoneserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/data"
+	"github.com/project-agonyl/open-agonyl-servers/internal/zoneserver/pathfinding"
+)
+
+func TestCanLoot(t *testing.T) {
+	owned := time.Now().Add(time.Minute)
+	expired := time.Now().Add(-time.Second)
+	party := &Party{LeaderId: 1, Members: []PartyMember{{PcId: 1}, {PcId: 2}}}
+	tests := []struct {
+		name   string
+		player *Player
+		item   *GroundItem
+		want   bool
+	}{
+		{"unowned item", &Player{PcId: 3}, &GroundItem{}, true},
+		{"owner", &Player{PcId: 1}, &GroundItem{OwnerPcId: 1, OwnedUntil: owned}, true},
+		{"party member of the owner", &Player{PcId: 2, Party: party}, &GroundItem{OwnerPcId: 1, OwnedUntil: owned}, true},
+		{"stranger while owned", &Player{PcId: 3}, &GroundItem{OwnerPcId: 1, OwnedUntil: owned}, false},
+		{"other party while owned", &Player{PcId: 3, Party: &Party{Members: []PartyMember{{PcId: 3}}}}, &GroundItem{OwnerPcId: 1, OwnedUntil: owned}, false},
+		{"stranger after ownership ran out", &Player{PcId: 3}, &GroundItem{OwnerPcId: 1, OwnedUntil: expired}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (&Zone{}).canLoot(tt.player, tt.item); got != tt.want {
+				t.Errorf("canLoot() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReturnDroppedItem(t *testing.T) {
+	tests := []struct {
+		name      string
+		inventory []InventoryItem
+		wantSlot  byte
+	}{
+		{"back to its old slot", []InventoryItem{{Slot: 0}}, 4},
+		{"old slot taken", []InventoryItem{{Slot: 0}, {Slot: 4}}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			player := &Player{Inventory: tt.inventory}
+			if !(&Zone{}).returnDroppedItem(player, InventoryItem{ItemCode: testSword, Slot: 4}) {
+				t.Fatal("returnDroppedItem() = false, want true")
+			}
+
+			index := findInventorySlot(player.Inventory, tt.wantSlot)
+			if len(player.Inventory) != len(tt.inventory)+1 || index < 0 || player.Inventory[index].ItemCode != testSword {
+				t.Fatalf("inventory = %v, want the item in slot %d", player.Inventory, tt.wantSlot)
+			}
+		})
+	}
+}
+
+func TestReturnDroppedItemInventoryFull(t *testing.T) {
+	inventory := make([]InventoryItem, inventorySize)
+	for slot := range inventory {
+		inventory[slot] = InventoryItem{Slot: byte(slot)}
+	}
+
+	mapData := &data.MapData{}
+	mapData.NavigationMesh[10][10] = data.NavigationData{IsMovable: true}
+	zone := &Zone{
+		grid:                   NewGrid(),
+		pathfinder:             pathfinding.NewPathfinder(pathfinding.NewMapGrid(mapData), pathfindingMaxNodes, 0),
+		groundItems:            make(map[uint32]*GroundItem),
+		groundItemCells:        make(map[uint32]uint32),
+		groundItemUidGenerator: shared.NewUidGenerator(groundItemUidStart),
+	}
+	player := &Player{PcId: 1, Inventory: inventory, Location: Location{X: 10, Y: 10}}
+	if zone.returnDroppedItem(player, InventoryItem{ItemCode: testSword, ItemUniqueCode: 7, Slot: 4}) {
+		t.Fatal("returnDroppedItem() = true with a full inventory")
+	}
+
+	if len(player.Inventory) != inventorySize || len(zone.groundItems) != 1 {
+		t.Fatalf("%d items in the inventory and %d on the ground", len(player.Inventory), len(zone.groundItems))
+	}
+
+	for _, item := range zone.groundItems {
+		if item.ItemUniqueCode != 7 || item.Location.X != 10 || item.Location.Y != 10 {
+			t.Errorf("ground item = %+v, want item 7 at 10, 10", item)
+		}
+
+		if item.OwnerPcId != player.PcId || !zone.canLoot(player, item) || zone.canLoot(&Player{PcId: 2}, item) {
+			t.Errorf("ground item = %+v, want it owned by player %d", item, player.PcId)
+		}
+	}
+}
@@ -18,9 +18,18 @@ type ZoneManager struct {
 	logger                shared.Logger
 	zones                 map[uint16]*Zone
 	npcsData              *shared.SafeMap[uint16, *data.NPCData]
+	mapsData              *shared.SafeMap[uint16, *data.MapData]
 	itemsData             map[uint32]*data.Item
+	dropTable             data.DropTable
+	shops                 map[uint16]*data.ShopData
+	consumables           data.ConsumableTable
+	skills                data.SkillTable
+	classStats            data.ClassStatTable
+	levels                data.LevelTable
+	partyExp              data.PartyExpTable
 	serialNumberGenerator shared.SerialNumberGenerator
 	players               *Players
+	mainServerClient      *MainServerClient
 	zoneWg                sync.WaitGroup
 }
 
@@ -38,12 +47,32 @@ func NewZoneManager(
 		logger:                logger,
 		zones:                 make(map[uint16]*Zone, len(cfg.MapIDs)),
 		npcsData:              shared.NewSafeMap[uint16, *data.NPCData](),
+		mapsData:              shared.NewSafeMap[uint16, *data.MapData](),
 		itemsData:             make(map[uint32]*data.Item),
+		dropTable:             data.DropTable{},
+		shops:                 make(map[uint16]*data.ShopData),
+		consumables:           data.ConsumableTable{},
+		skills:                data.SkillTable{},
+		classStats:            data.ClassStatTable{},
+		levels:                data.LevelTable{},
 		serialNumberGenerator: serialNumberGenerator,
 		players:               players,
 	}
 }
 
+// SetMainServerClient must be called before Start.
+func (m *ZoneManager) SetMainServerClient(mainServerClient *MainServerClient) {
+	m.mainServerClient = mainServerClient
+}
+
+func (m *ZoneManager) SendToMainServer(packet []byte) error {
+	if m.mainServerClient == nil {
+		return errors.New("main server client is not set")
+	}
+
+	return m.mainServerClient.Send(packet)
+}
+
 func (m *ZoneManager) Start() error {
 	m.logger.Info("Loading IT0 data...")
 	it0, err := data.LoadIT0Items(m.cfg.ZoneDataItemPath+"/0", m.cfg.ZoneDataItemPath+"/0ex")
@@ -104,6 +133,105 @@ func (m *ZoneManager) Start() error {
 		m.itemsData[item.ItemCode] = &item
 	}
 
+	m.logger.Info("Loading shop data...")
+	shops, skippedShopFiles, err := data.LoadShops(m.cfg.ZoneDataShopPath)
+	if err != nil {
+		m.logger.Warn(
+			"Error loading shop data, merchants will not trade",
+			shared.Field{Key: "path", Value: m.cfg.ZoneDataShopPath},
+			shared.Field{Key: "error", Value: err},
+		)
+	} else {
+		for _, fileName := range skippedShopFiles {
+			m.logger.Warn(
+				"Skipped shop file not named after an NPC id",
+				shared.Field{Key: "path", Value: m.cfg.ZoneDataShopPath},
+				shared.Field{Key: "fileName", Value: fileName},
+			)
+		}
+
+		m.shops = shops
+		m.logger.Info("Loaded shops", shared.Field{Key: "count", Value: len(shops)})
+	}
+
+	m.logger.Info("Loading drop table...")
+	dropTable, err := data.LoadDropTable(m.cfg.ZoneDataDropPath)
+	if err != nil {
+		m.logger.Warn(
+			"Error loading drop table, monsters will not drop items",
+			shared.Field{Key: "path", Value: m.cfg.ZoneDataDropPath},
+			shared.Field{Key: "error", Value: err},
+		)
+	} else {
+		m.dropTable = dropTable
+		m.logger.Info("Loaded drop table", shared.Field{Key: "count", Value: len(dropTable)})
+	}
+
+	m.logger.Info("Loading consumable table...")
+	consumables, err := data.LoadConsumableTable(m.cfg.ZoneDataConsumablePath)
+	if err != nil {
+		m.logger.Warn(
+			"Error loading consumable table, items will not be usable",
+			shared.Field{Key: "path", Value: m.cfg.ZoneDataConsumablePath},
+			shared.Field{Key: "error", Value: err},
+		)
+	} else {
+		m.consumables = consumables
+		m.logger.Info("Loaded consumable table", shared.Field{Key: "count", Value: len(consumables)})
+	}
+
+	m.logger.Info("Loading skill table...")
+	skills, err := data.LoadSkillTable(m.cfg.ZoneDataSkillPath)
+	if err != nil {
+		m.logger.Warn(
+			"Error loading skill table, skills will not be usable",
+			shared.Field{Key: "path", Value: m.cfg.ZoneDataSkillPath},
+			shared.Field{Key: "error", Value: err},
+		)
+	} else {
+		m.skills = skills
+		m.logger.Info("Loaded skill table", shared.Field{Key: "count", Value: len(skills)})
+	}
+
+	m.logger.Info("Loading class stat table...")
+	classStats, err := data.LoadClassStatTable(m.cfg.ZoneDataClassStatPath)
+	if err != nil {
+		m.logger.Warn(
+			"Error loading class stat table, stat points will not be allottable",
+			shared.Field{Key: "path", Value: m.cfg.ZoneDataClassStatPath},
+			shared.Field{Key: "error", Value: err},
+		)
+	} else {
+		m.classStats = classStats
+		m.logger.Info("Loaded class stat table", shared.Field{Key: "count", Value: len(classStats)})
+	}
+
+	m.logger.Info("Loading level table...")
+	levels, err := data.LoadLevelTable(m.cfg.ZoneDataLevelPath)
+	if err != nil {
+		m.logger.Warn(
+			"Error loading level table, players will not gain experience",
+			shared.Field{Key: "path", Value: m.cfg.ZoneDataLevelPath},
+			shared.Field{Key: "error", Value: err},
+		)
+	} else {
+		m.levels = levels
+		m.logger.Info("Loaded level table", shared.Field{Key: "count", Value: len(levels)})
+	}
+
+	m.logger.Info("Loading party exp table...")
+	partyExp, err := data.LoadPartyExpTable(m.cfg.ZoneDataPartyExpPath)
+	if err != nil {
+		m.logger.Warn(
+			"Error loading party exp table, kill experience will not be shared",
+			shared.Field{Key: "path", Value: m.cfg.ZoneDataPartyExpPath},
+			shared.Field{Key: "error", Value: err},
+		)
+	} else {
+		m.partyExp = partyExp
+		m.logger.Info("Loaded party exp table", shared.Field{Key: "count", Value: len(partyExp.Bands)})
+	}
+
 	m.logger.Info("Loading zones...")
 	for _, mapId := range m.cfg.MapIDs {
 		zone, err := NewZone(m.cfg, m.db, m.logger, mapId, m.players, m)
@@ -148,6 +276,12 @@ func (m *ZoneManager) GetZone(mapId uint16) *Zone {
 	return m.zones[mapId]
 }
 
+func (m *ZoneManager) ForEachZone(fn func(zone *Zone)) {
+	for _, zone := range m.zones {
+		fn(zone)
+	}
+}
+
 func (m *ZoneManager) GetZoneByPlayer(player *Player) *Zone {
 	return m.zones[player.Zone.mapId]
 }
@@ -176,6 +310,23 @@ func (m *ZoneManager) GetNPCData(npcId uint16) (*data.NPCData, error) {
 	return npcData, nil
 }
 
+// GetMapData loads the map data of any map, not only the ones this server
+// runs, so that warps can be checked against the map they lead to.
+func (m *ZoneManager) GetMapData(mapId uint16) (*data.MapData, error) {
+	mapData, exists := m.mapsData.Get(mapId)
+	if !exists {
+		mapData, err := data.LoadMapData(m.cfg.ZoneDataMapPath + "/" + strconv.Itoa(int(mapId)))
+		if err != nil {
+			return nil, err
+		}
+
+		m.mapsData.Set(mapId, mapData)
+		return mapData, nil
+	}
+
+	return mapData, nil
+}
+
 func (m *ZoneManager) GetItemData(itemCode uint32) (*data.Item, error) {
 	itemData, exists := m.itemsData[itemCode]
 	if !exists {
@@ -185,6 +336,40 @@ func (m *ZoneManager) GetItemData(itemCode uint32) (*data.Item, error) {
 	return itemData, nil
 }
 
+func (m *ZoneManager) GetShop(npcId uint16) (*data.ShopData, bool) {
+	shop, exists := m.shops[npcId]
+	return shop, exists
+}
+
+func (m *ZoneManager) GetDropItems(npcId uint16) []data.DropItem {
+	return m.dropTable[npcId]
+}
+
+func (m *ZoneManager) GetConsumable(itemCode uint32) (data.Consumable, bool) {
+	consumable, exists := m.consumables[itemCode]
+	return consumable, exists
+}
+
+func (m *ZoneManager) GetSkill(class byte, skillId byte) (data.SkillData, bool) {
+	return m.skills.Get(class, skillId)
+}
+
+func (m *ZoneManager) GetClassStats(class byte) (data.ClassStats, bool) {
+	return m.classStats.Get(class)
+}
+
+func (m *ZoneManager) GetLevelTable() data.LevelTable {
+	return m.levels
+}
+
+func (m *ZoneManager) GetPartyExpTable() data.PartyExpTable {
+	return m.partyExp
+}
+
+func (m *ZoneManager) FindSkillByBook(class byte, itemCode uint32) (byte, bool) {
+	return m.skills.FindByBook(class, itemCode)
+}
+
 func (z *ZoneManager) EnqueuePlayerPacket(mapId uint16, packet []byte) bool {
 	zone, exists := z.zones[mapId]
 	if !exists {
@@ -0,0 +1,51 @@
+package zoneserver
+
+import (
+	"container/heap"
+	"time"
+)
+
+type zoneTimer struct {
+	at       time.Time
+	callback func()
+}
+
+// zoneTimers is a min-heap of timers ordered by their due time. It is not
+// goroutine-safe and must only be touched from the zone goroutine.
+type zoneTimers []*zoneTimer
+
+func (t zoneTimers) Len() int {
+	return len(t)
+}
+
+func (t zoneTimers) Less(i, j int) bool {
+	return t[i].at.Before(t[j].at)
+}
+
+func (t zoneTimers) Swap(i, j int) {
+	t[i], t[j] = t[j], t[i]
+}
+
+func (t *zoneTimers) Push(x any) {
+	*t = append(*t, x.(*zoneTimer))
+}
+
+func (t *zoneTimers) Pop() any {
+	old := *t
+	n := len(old)
+	timer := old[n-1]
+	old[n-1] = nil
+	*t = old[:n-1]
+	return timer
+}
+
+func (t *zoneTimers) schedule(at time.Time, callback func()) {
+	heap.Push(t, &zoneTimer{at: at, callback: callback})
+}
+
+func (t *zoneTimers) advance(now time.Time) {
+	for t.Len() > 0 && !(*t)[0].at.After(now) {
+		timer := heap.Pop(t).(*zoneTimer)
+		timer.callback()
+	}
+}
@@ -0,0 +1,115 @@
+package zoneserver
+
+import (
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared"
+	"github.com/project-agonyl/open-agonyl-servers/internal/shared/messages"
+)
+
+func (z *Zone) addPlayerToWorld(player *Player) {
+	z.grid.AddPlayer(player)
+	appear := z.newPcAppearMsg(player).GetBytes()
+	z.grid.ForEachPlayerInRange(player.Location.X, player.Location.Y, func(other *Player) bool {
+		if other.PcId == player.PcId {
+			return true
+		}
+
+		z.relay(other, appear)
+		z.relay(player, z.newPcAppearMsg(other).GetBytes())
+		return true
+	})
+	z.grid.ForEachNPCInRange(player.Location.X, player.Location.Y, func(npc *NPC) bool {
+		z.relay(player, z.newNpcInitializeMsg(npc).GetBytes())
+		return true
+	})
+	z.grid.ForEachItemInRange(player.Location.X, player.Location.Y, func(item *GroundItem) bool {
+		z.relay(player, newItemAppearMsg(player.PcId, item).GetBytes())
+		return true
+	})
+}
+
+func (z *Zone) removePlayerFromWorld(player *Player) {
+	z.grid.RemovePlayer(player)
+	disappear := messages.NewMsgS2CPcDisappear(player.PcId, player.PcId).GetBytes()
+	z.BroadcastNearby(player.Location.X, player.Location.Y, disappear, player.PcId)
+}
+
+// movePlayerInWorld moves the player to the given cell and exchanges appear
+// and disappear messages with the players that went in or out of view. NPCs
+// and items going in or out of view are shown or hidden for the player.
+func (z *Zone) movePlayerInWorld(player *Player, x byte, y byte) {
+	left, entered := z.grid.MovePlayer(player, x, y)
+	player.MarkDirty()
+	if len(left) == 0 && len(entered) == 0 {
+		return
+	}
+
+	disappear := messages.NewMsgS2CPcDisappear(player.PcId, player.PcId).GetBytes()
+	for _, sector := range left {
+		for _, other := range sector.players {
+			z.relay(other, disappear)
+			z.relay(player, messages.NewMsgS2CPcDisappear(other.PcId, other.PcId).GetBytes())
+		}
+
+		for _, npc := range sector.npcs {
+			z.relay(player, newNpcDisappearMsg(npc).GetBytes())
+		}
+
+		for _, item := range sector.items {
+			z.relay(player, messages.NewMsgS2CItemDisappear(player.PcId, item.Id).GetBytes())
+		}
+	}
+
+	appear := z.newPcAppearMsg(player).GetBytes()
+	for _, sector := range entered {
+		for _, other := range sector.players {
+			if other.PcId == player.PcId {
+				continue
+			}
+
+			z.relay(other, appear)
+			z.relay(player, z.newPcAppearMsg(other).GetBytes())
+		}
+
+		for _, npc := range sector.npcs {
+			z.relay(player, z.newNpcInitializeMsg(npc).GetBytes())
+		}
+
+		for _, item := range sector.items {
+			z.relay(player, newItemAppearMsg(player.PcId, item).GetBytes())
+		}
+	}
+}
+
+// BroadcastNearby relays the packet to every player that can see the given
+// cell, except the player with exceptPcId.
+func (z *Zone) BroadcastNearby(x byte, y byte, packet []byte, exceptPcId uint32) {
+	z.grid.ForEachPlayerInRange(x, y, func(player *Player) bool {
+		if player.PcId != exceptPcId {
+			z.relay(player, packet)
+		}
+
+		return true
+	})
+}
+
+func (z *Zone) relay(player *Player, packet []byte) {
+	if err := player.Relay(packet); err != nil {
+		z.logger.Error(
+			"Failed to relay packet to player",
+			shared.Field{Key: "error", Value: err},
+			shared.Field{Key: "mapId", Value: z.mapId},
+			shared.Field{Key: "pcId", Value: player.PcId},
+		)
+	}
+}
+
+func (z *Zone) newPcAppearMsg(player *Player) *messages.MsgS2CPcAppear {
+	msg := messages.NewMsgS2CPcAppear(player.PcId, player.PcId, player.CharacterName)
+	msg.Class = player.Class
+	msg.Level = player.Level
+	msg.MapCell = player.Location.Cell()
+	msg.SocialInfo = newSocialInfoMsg(player.SocialInfo)
+	msg.WearList = newWearListMsg(player.Wear)
+	msg.ActivePet = newPetMsg(player.ActivePet)
+	return msg
+}